@@ -2,29 +2,42 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/chrisreddington/gh-game/internal/cointoss"
-	userPrompt "github.com/cli/go-gh/v2/pkg/prompter"
+	"github.com/chrisreddington/gh-game/internal/registry"
 	"github.com/spf13/cobra"
 )
 
-var cointossCmd = &cobra.Command{
-	Use:   "cointoss [guess]",
-	Short: "Toss a coin",
-	Long:  `Toss a virtual coin and get heads or tails as the result.`,
-	Args: func(cmd *cobra.Command, args []string) error {
-		if len(args) != 1 {
-			return fmt.Errorf("requires exactly 1 argument (guess)")
-		}
-		return cointoss.ValidateGuess(args[0])
-	},
-	Run: func(cmd *cobra.Command, args []string) {
-		input := userPrompt.New(os.Stdin, os.Stdout, os.Stderr)
-		cointoss.PlayGame(input, args[0])
-	},
+func newCointossCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:       "cointoss [guess]",
+		Short:     "Toss a coin",
+		Long:      `Toss a virtual coin and get heads or tails as the result.`,
+		ValidArgs: []string{"heads", "tails"},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("requires exactly 1 argument (guess)")
+			}
+			return cointoss.ValidateGuess(args[0])
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			session, err := newGameSession("cointoss")
+			if err != nil {
+				return err
+			}
+			return session.finish(cointoss.PlayGame(session.prompter, session.out, session.rng, args[0]))
+		},
+	}
 }
 
 func init() {
-	rootCmd.AddCommand(cointossCmd)
+	registry.Register(registry.Game{
+		Name:           "cointoss",
+		Description:    "Guess heads or tails and build a winning streak",
+		MinPlayers:     1,
+		MaxPlayers:     1,
+		Modes:          []string{"solo"},
+		ScoredByStreak: true,
+		NewCommand:     newCointossCmd,
+	})
 }
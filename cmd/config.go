@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chrisreddington/gh-game/internal/config"
+	"github.com/chrisreddington/gh-game/internal/registry"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// userConfig is the configuration file, loaded once per run
+var userConfig *config.Config
+
+// loadConfig returns the user's configuration.
+func loadConfig() (*config.Config, error) {
+	if userConfig != nil {
+		return userConfig, nil
+	}
+	cfg, err := config.Load(config.DefaultPath())
+	if err != nil {
+		return nil, err
+	}
+	userConfig = cfg
+	return cfg, nil
+}
+
+// applyGlobalConfig fills in the global flags that were not given on the
+// command line from the configuration file.
+func applyGlobalConfig() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	return applyConfig(cfg, rootCmd.PersistentFlags(), "")
+}
+
+// newGameCommand creates a game's command. Flags that are not given on the
+// command line take their values from the game's section of the
+// configuration file. A game checks its flags in PreRunE, which runs once the
+// configured values are in place.
+func newGameCommand(game registry.Game) *cobra.Command {
+	cmd := game.NewCommand()
+	preRun := cmd.PreRunE
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if err := applyConfig(cfg, cmd.LocalNonPersistentFlags(), game.Name); err != nil {
+			return err
+		}
+		if preRun != nil {
+			return preRun(cmd, args)
+		}
+		return nil
+	}
+	return cmd
+}
+
+// applyConfig sets every flag in flags that was not changed on the command
+// line to its configured value. Game flags are looked up under the game's
+// name and are not marked as changed, so a game can tell a flag given on the
+// command line from a configured default; an empty game looks up global
+// flags, which count as given.
+func applyConfig(cfg *config.Config, flags *pflag.FlagSet, game string) error {
+	var err error
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if err != nil || flag.Changed {
+			return
+		}
+		key := configKey(game, flag.Name)
+		value, ok := cfg.Get(key)
+		if !ok {
+			return
+		}
+		var setErr error
+		if game == "" {
+			setErr = flags.Set(flag.Name, value)
+		} else {
+			setErr = flag.Value.Set(value)
+		}
+		if setErr != nil {
+			err = fmt.Errorf("invalid value %q for %s in %s: %w", value, key, config.DefaultPath(), setErr)
+		}
+	})
+	return err
+}
+
+// configKey joins a game name and a flag name into a configuration key.
+func configKey(game, flag string) string {
+	if game == "" {
+		return flag
+	}
+	return game + "." + flag
+}
+
+// lookupConfigFlag finds the flag a configuration key refers to, along with
+// the command it belongs to.
+func lookupConfigFlag(key string) (*cobra.Command, *pflag.Flag, error) {
+	if err := config.ValidateKey(key); err != nil {
+		return nil, nil, err
+	}
+
+	name, flagName, ok := strings.Cut(key, ".")
+	if key == "moves" || key == "record" {
+		return nil, nil, fmt.Errorf("%s can only be given on the command line", key)
+	}
+	if !ok {
+		flag := rootCmd.PersistentFlags().Lookup(key)
+		if flag == nil {
+			return nil, nil, fmt.Errorf("unknown setting %q: use a global flag such as seed, or <game>.<flag>", key)
+		}
+		return rootCmd, flag, nil
+	}
+
+	game, ok := registry.Lookup(name)
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown game %q, run 'gh game list' to see the available games", name)
+	}
+	cmd := game.NewCommand()
+	flag := cmd.LocalNonPersistentFlags().Lookup(flagName)
+	if flag == nil || flag.Name == "help" {
+		return nil, nil, fmt.Errorf("%s has no --%s flag", name, flagName)
+	}
+	return cmd, flag, nil
+}
+
+// validateConfigValue checks that value would be accepted by flag, so a
+// mistake is caught when it is set rather than the next time a game starts.
+func validateConfigValue(cmd *cobra.Command, flag *pflag.Flag, value string) error {
+	var err error
+	switch flag.Value.Type() {
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	case "int":
+		_, err = strconv.Atoi(value)
+	case "int64":
+		_, err = strconv.ParseInt(value, 10, 64)
+	case "float64":
+		_, err = strconv.ParseFloat(value, 64)
+	case "duration":
+		_, err = time.ParseDuration(value)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value %q for --%s: expected a %s", value, flag.Name, flag.Value.Type())
+	}
+
+	if complete, ok := cmd.GetFlagCompletionFunc(flag.Name); ok && value != "" {
+		completions, _ := complete(cmd, nil, "")
+		var choices []string
+		for _, completion := range completions {
+			choice, _, _ := strings.Cut(completion, "\t")
+			choices = append(choices, choice)
+		}
+		if !slices.Contains(choices, value) {
+			return fmt.Errorf("invalid value %q for --%s: must be one of %s", value, flag.Name, strings.Join(choices, ", "))
+		}
+	}
+	return nil
+}
+
+// validateGameConfig checks that the game key belongs to would still accept
+// its flags once key is set to value, by running the game's own checks with
+// the rest of its configured values applied.
+func validateGameConfig(cfg *config.Config, cmd *cobra.Command, key, value string) error {
+	game, flagName, ok := strings.Cut(key, ".")
+	if !ok || cmd.PreRunE == nil {
+		return nil
+	}
+	flags := cmd.LocalNonPersistentFlags()
+	if err := flags.Set(flagName, value); err != nil {
+		return err
+	}
+	if err := applyConfig(cfg, flags, game); err != nil {
+		return err
+	}
+	if err := cmd.PreRunE(cmd, nil); err != nil {
+		return fmt.Errorf("invalid value %q for %s: %w", value, key, err)
+	}
+	return nil
+}
+
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage your default game settings",
+		Long: `Manage default settings that apply every time you play. Settings are
+stored in config.yaml in the gh-game directory inside the GitHub CLI config
+directory, and a flag given on the command line always takes precedence.
+
+A key is either a global flag, such as seed, or a game name and one of its
+flags joined by a dot, such as higherlower.max.
+
+Example usage:
+  gh game config set higherlower.max 1000
+  gh game config set rockpaperscissors.rounds 5
+  gh game config set tictactoe.mode computer
+  gh game config get higherlower.max
+  gh game config list
+  gh game config unset higherlower.max`,
+	}
+
+	// Skip the global settings so a bad value can still be fixed
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error { return nil }
+	cmd.AddCommand(newConfigGetCmd(), newConfigSetCmd(), newConfigUnsetCmd(), newConfigListCmd())
+	return cmd
+}
+
+func newConfigGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the value of a setting",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, _, err := lookupConfigFlag(args[0]); err != nil {
+				return err
+			}
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			value, ok := cfg.Get(args[0])
+			if !ok {
+				return fmt.Errorf("%s is not set", args[0])
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), value)
+			return nil
+		},
+	}
+}
+
+func newConfigSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Change the default value of a setting",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, value := args[0], args[1]
+			flagCmd, flag, err := lookupConfigFlag(key)
+			if err != nil {
+				return err
+			}
+			if err := validateConfigValue(flagCmd, flag, value); err != nil {
+				return err
+			}
+
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			if err := validateGameConfig(cfg, flagCmd, key, value); err != nil {
+				return err
+			}
+			if err := cfg.Set(key, value); err != nil {
+				return err
+			}
+			return cfg.Save()
+		},
+	}
+}
+
+func newConfigUnsetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset <key>",
+		Short: "Go back to the built-in default for a setting",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			if !cfg.Unset(args[0]) {
+				return fmt.Errorf("%s is not set", args[0])
+			}
+			return cfg.Save()
+		},
+	}
+}
+
+func newConfigListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List every setting you have changed",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				values := map[string]string{}
+				for _, key := range cfg.Keys() {
+					values[key], _ = cfg.Get(key)
+				}
+				return writeJSON(cmd.OutOrStdout(), values)
+			}
+
+			for _, key := range cfg.Keys() {
+				value, _ := cfg.Get(key)
+				fmt.Fprintf(cmd.OutOrStdout(), "%s=%s\n", key, value)
+			}
+			return nil
+		},
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(newConfigCmd())
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/cli/go-gh/v2/pkg/term"
+)
+
+// writeJSON writes v as indented JSON.
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// newTablePrinter creates a table printer sized to the terminal. When the
+// output is not a terminal the table is written as tab-separated values.
+func newTablePrinter(w io.Writer) tableprinter.TablePrinter {
+	terminal := term.FromEnv()
+	width, _, err := terminal.Size()
+	if err != nil {
+		width = 80
+	}
+	return tableprinter.New(w, terminal.IsTerminalOutput(), width)
+}
@@ -1,23 +1,24 @@
 package cmd
 
 import (
-	"os"
+	"fmt"
 
 	"github.com/chrisreddington/gh-game/internal/higherlower"
-	userPrompt "github.com/cli/go-gh/v2/pkg/prompter"
+	"github.com/chrisreddington/gh-game/internal/registry"
 	"github.com/spf13/cobra"
 )
 
-var (
-	minNumber int
-	maxNumber int
-)
+func newHigherLowerCmd() *cobra.Command {
+	var (
+		minNumber int
+		maxNumber int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "higherlower",
+		Short: "Play Higher or Lower",
+		Long: `Play a Higher or Lower number guessing game.
 
-var higherLowerCmd = &cobra.Command{
-	Use:   "higherlower",
-	Short: "Play Higher or Lower",
-	Long: `Play a Higher or Lower number guessing game.
-	
 A number will be shown and you need to guess whether the
 next number will be higher or lower than the current number.
 
@@ -27,16 +28,36 @@ can you get?
 Example usage:
   gh game higherlower
   gh game higherlower --min 1 --max 1000`,
-	Args: cobra.NoArgs,
-	Run: func(cmd *cobra.Command, args []string) {
-		input := userPrompt.New(os.Stdin, os.Stdout, os.Stderr)
-		higherlower.PlayGame(input, minNumber, maxNumber)
-	},
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if minNumber >= maxNumber {
+				return fmt.Errorf("min must be less than max, got min %d and max %d", minNumber, maxNumber)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			session, err := newGameSession("higherlower")
+			if err != nil {
+				return err
+			}
+			return session.finish(higherlower.PlayGame(session.prompter, session.out, session.rng, minNumber, maxNumber))
+		},
+	}
+
+	cmd.Flags().IntVarP(&minNumber, "min", "m", 1, "Minimum possible number")
+	cmd.Flags().IntVarP(&maxNumber, "max", "M", 100, "Maximum possible number")
+
+	return cmd
 }
 
 func init() {
-	higherLowerCmd.Flags().IntVarP(&minNumber, "min", "m", 1, "Minimum possible number")
-	higherLowerCmd.Flags().IntVarP(&maxNumber, "max", "M", 100, "Maximum possible number")
-
-	rootCmd.AddCommand(higherLowerCmd)
+	registry.Register(registry.Game{
+		Name:           "higherlower",
+		Description:    "Guess whether the next number is higher or lower",
+		MinPlayers:     1,
+		MaxPlayers:     1,
+		Modes:          []string{"solo"},
+		ScoredByStreak: true,
+		NewCommand:     newHigherLowerCmd,
+	})
 }
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chrisreddington/gh-game/internal/config"
+	"github.com/chrisreddington/gh-game/internal/launcher"
+	"github.com/chrisreddington/gh-game/internal/registry"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// runLauncher shows the full-screen game menu when gh game is run without a
+// subcommand, and starts the chosen games until the player quits. Without a
+// terminal, or when the output is meant for scripts, it prints help instead.
+func runLauncher(cmd *cobra.Command, args []string) error {
+	if jsonOutput || movesPath != "" || !term.FromEnv().IsTerminalOutput() || !term.IsTerminal(os.Stdin) {
+		return cmd.Help()
+	}
+
+	menu := launcher.New(launcherGames())
+	for {
+		selection, ok, err := menu.Run()
+		if err != nil || !ok {
+			return err
+		}
+
+		game, _ := registry.Lookup(selection.Game)
+		gameCmd := newGameCommand(game)
+		gameCmd.SetArgs(selection.Args)
+		gameCmd.SilenceErrors = true
+		gameCmd.SilenceUsage = true
+		if err := gameCmd.Execute(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+
+		fmt.Print("\nPress Enter to return to the menu...")
+		if _, err := bufio.NewReader(os.Stdin).ReadString('\n'); err != nil {
+			return nil
+		}
+	}
+}
+
+// launcherGames lists every registered game with the options its command
+// accepts. Flags become options starting from their configured values, and a
+// command with ValidArgs gets a choice for its first argument.
+func launcherGames() []launcher.Game {
+	cfg, err := loadConfig()
+	if err != nil {
+		cfg, _ = config.Load("")
+	}
+
+	var games []launcher.Game
+	for _, game := range registry.Games() {
+		gameCmd := game.NewCommand()
+		applyConfig(cfg, gameCmd.LocalNonPersistentFlags(), game.Name)
+		entry := launcher.Game{Name: game.Name, Description: game.Description}
+
+		if len(gameCmd.ValidArgs) > 0 {
+			entry.Options = append(entry.Options, launcher.Option{
+				Name:       argumentName(gameCmd),
+				Kind:       launcher.Choice,
+				Choices:    gameCmd.ValidArgs,
+				Default:    gameCmd.ValidArgs[0],
+				Positional: true,
+			})
+		}
+
+		gameCmd.LocalNonPersistentFlags().VisitAll(func(flag *pflag.Flag) {
+			if flag.Name == "help" {
+				return
+			}
+			entry.Options = append(entry.Options, flagOption(gameCmd, flag))
+		})
+
+		games = append(games, entry)
+	}
+	return games
+}
+
+// flagOption describes a flag as a launcher option. Flags with completions
+// cycle through the completed values.
+func flagOption(gameCmd *cobra.Command, flag *pflag.Flag) launcher.Option {
+	option := launcher.Option{
+		Name:    flag.Name,
+		Usage:   flag.Usage,
+		Kind:    launcher.Text,
+		Default: flag.Value.String(),
+	}
+
+	if flag.Value.Type() == "bool" {
+		option.Kind = launcher.Toggle
+		return option
+	}
+
+	if complete, ok := gameCmd.GetFlagCompletionFunc(flag.Name); ok {
+		completions, _ := complete(gameCmd, nil, "")
+		if flag.DefValue == "" {
+			option.Choices = append(option.Choices, "")
+		}
+		for _, completion := range completions {
+			value, _, _ := strings.Cut(completion, "\t")
+			option.Choices = append(option.Choices, value)
+		}
+		option.Kind = launcher.Choice
+	}
+	return option
+}
+
+// argumentName returns the name of a command's first argument from its usage
+// line, such as "guess" for "cointoss [guess]".
+func argumentName(gameCmd *cobra.Command) string {
+	fields := strings.Fields(gameCmd.Use)
+	if len(fields) < 2 {
+		return "argument"
+	}
+	return strings.Trim(fields[1], "[]<>")
+}
+
+func init() {
+	rootCmd.RunE = runLauncher
+}
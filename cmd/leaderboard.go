@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/chrisreddington/gh-game/internal/leaderboard"
+	"github.com/chrisreddington/gh-game/internal/registry"
+	"github.com/chrisreddington/gh-game/internal/stats"
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func newLeaderboardCmd() *cobra.Command {
+	var gistID string
+
+	cmd := &cobra.Command{
+		Use:   "leaderboard",
+		Short: "Share your records on a team leaderboard",
+		Long: `Share your best streaks and win records with your team through a GitHub gist.
+
+Push your local statistics to the leaderboard gist, then pull it to see how
+everyone ranks. Pushing without a gist creates a new secret gist whose ID
+you can share with your team.
+
+Example usage:
+  gh game leaderboard push
+  gh game leaderboard push --gist <id>
+  gh game leaderboard pull --gist <id>
+  gh game leaderboard pull cointoss --gist <id>`,
+	}
+
+	cmd.PersistentFlags().StringVar(&gistID, "gist", "", "ID of the gist holding the leaderboard")
+	cmd.AddCommand(newLeaderboardPushCmd(&gistID), newLeaderboardPullCmd(&gistID))
+
+	return cmd
+}
+
+func newLeaderboardPushCmd(gistID *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "push",
+		Short: "Add your statistics to the leaderboard",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := stats.Load(stats.DefaultPath())
+			if err != nil {
+				return err
+			}
+			if len(store.Games) == 0 {
+				return fmt.Errorf("no games recorded yet, play a game before pushing to the leaderboard")
+			}
+
+			client, err := newLeaderboardClient()
+			if err != nil {
+				return err
+			}
+			login, err := client.CurrentUser()
+			if err != nil {
+				return err
+			}
+
+			id, board, err := client.Push(*gistID, login, leaderboard.FromStats(store, time.Now()))
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if *gistID == "" {
+				fmt.Fprintf(out, "Created leaderboard gist %s\n", id)
+				fmt.Fprintf(out, "Share it with your team: gh game leaderboard pull --gist %s\n\n", id)
+			} else {
+				fmt.Fprintf(out, "Pushed statistics for %s to gist %s\n\n", login, id)
+			}
+			return printLeaderboard(out, board, board.Games())
+		},
+	}
+}
+
+func newLeaderboardPullCmd(gistID *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pull [game]",
+		Short: "Show the ranked leaderboard",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if *gistID == "" {
+				return fmt.Errorf("a leaderboard gist is required, pass one with --gist")
+			}
+
+			client, err := newLeaderboardClient()
+			if err != nil {
+				return err
+			}
+			board, err := client.Fetch(*gistID)
+			if err != nil {
+				return err
+			}
+
+			games := board.Games()
+			if len(args) == 1 {
+				if _, ok := registry.Lookup(args[0]); !ok {
+					return fmt.Errorf("unknown game %q, run 'gh game list' to see the available games", args[0])
+				}
+				games = []string{args[0]}
+			}
+
+			if jsonOutput {
+				rankings := map[string][]leaderboard.Ranking{}
+				for _, game := range games {
+					rankings[game] = board.Rank(game, scoredByStreak(game))
+				}
+				return writeJSON(cmd.OutOrStdout(), rankings)
+			}
+			return printLeaderboard(cmd.OutOrStdout(), board, games)
+		},
+	}
+
+	return cmd
+}
+
+// newLeaderboardClient creates a leaderboard client authenticated as the current gh user.
+func newLeaderboardClient() (*leaderboard.Client, error) {
+	rest, err := api.DefaultRESTClient()
+	if err != nil {
+		return nil, fmt.Errorf("connecting to GitHub: %w", err)
+	}
+	return leaderboard.NewClient(rest), nil
+}
+
+// scoredByStreak reports whether the named game ranks players by their best streak.
+func scoredByStreak(name string) bool {
+	game, ok := registry.Lookup(name)
+	return ok && game.ScoredByStreak
+}
+
+// printLeaderboard writes a ranked table for each of the given games.
+func printLeaderboard(w io.Writer, board leaderboard.Board, games []string) error {
+	if len(games) == 0 {
+		_, err := fmt.Fprintln(w, "The leaderboard is empty.")
+		return err
+	}
+
+	for i, game := range games {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, game)
+
+		rankings := board.Rank(game, scoredByStreak(game))
+		if len(rankings) == 0 {
+			fmt.Fprintln(w, "No records yet.")
+			continue
+		}
+
+		table := newTablePrinter(w)
+		table.AddHeader([]string{"RANK", "PLAYER", "BEST STREAK", "WINS", "LOSSES", "DRAWS", "UPDATED"})
+		for _, ranking := range rankings {
+			table.AddField(fmt.Sprintf("%d", ranking.Rank))
+			table.AddField(ranking.Player)
+			table.AddField(fmt.Sprintf("%d", ranking.BestStreak))
+			table.AddField(fmt.Sprintf("%d", ranking.Wins))
+			table.AddField(fmt.Sprintf("%d", ranking.Losses))
+			table.AddField(fmt.Sprintf("%d", ranking.Draws))
+			table.AddField(formatTime(ranking.UpdatedAt))
+			table.EndRow()
+		}
+		if err := table.Render(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newLeaderboardCmd())
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/chrisreddington/gh-game/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+func newListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the available games",
+		Long: `List every game installed in gh-game along with its description,
+supported player counts and modes.
+
+Example usage:
+  gh game list
+  gh game list --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			games := registry.Games()
+			if jsonOutput {
+				return writeJSON(cmd.OutOrStdout(), games)
+			}
+			return printGamesTable(cmd.OutOrStdout(), games)
+		},
+	}
+
+	return cmd
+}
+
+// printGamesTable writes the catalogue as a table, falling back to
+// tab-separated values when the output is not a terminal.
+func printGamesTable(w io.Writer, games []registry.Game) error {
+	table := newTablePrinter(w)
+	table.AddHeader([]string{"NAME", "DESCRIPTION", "PLAYERS", "MODES", "AI"})
+	for _, game := range games {
+		players := fmt.Sprintf("%d", game.MinPlayers)
+		if game.MaxPlayers != game.MinPlayers {
+			players = fmt.Sprintf("%d-%d", game.MinPlayers, game.MaxPlayers)
+		}
+		ai := "no"
+		if game.HasAI {
+			ai = "yes"
+		}
+
+		table.AddField(game.Name)
+		table.AddField(game.Description)
+		table.AddField(players)
+		table.AddField(strings.Join(game.Modes, ", "))
+		table.AddField(ai)
+		table.EndRow()
+	}
+	return table.Render()
+}
+
+func init() {
+	rootCmd.AddCommand(newListCmd())
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/chrisreddington/gh-game/internal/output"
+	"github.com/chrisreddington/gh-game/internal/record"
+	"github.com/spf13/cobra"
+)
+
+func newReplayCmd() *cobra.Command {
+	player := &record.Player{}
+
+	cmd := &cobra.Command{
+		Use:   "replay <file>",
+		Short: "Play back a recorded game session",
+		Long: `Play back a session recorded with --record, step by step with its original
+rendering and pacing.
+
+Example usage:
+  gh game wordguess --record session.jsonl
+  gh game replay session.jsonl
+  gh game replay session.jsonl --speed 4
+  gh game replay session.jsonl --speed 0 --events
+  gh game replay session.jsonl --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if player.Speed < 0 {
+				return fmt.Errorf("speed must not be negative")
+			}
+
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			events, err := record.Read(file)
+			if err != nil {
+				return fmt.Errorf("reading %s: %w", args[0], err)
+			}
+
+			if jsonOutput {
+				out := output.NewJSON(cmd.OutOrStdout())
+				for _, event := range events {
+					if event.Kind == record.KindEvent {
+						out.Event(event.Name, event.Fields)
+					}
+				}
+				return nil
+			}
+
+			player.Out = cmd.OutOrStdout()
+			player.Play(events)
+			return nil
+		},
+	}
+
+	cmd.Flags().Float64Var(&player.Speed, "speed", 1, "Playback speed multiplier, or 0 to play without pauses")
+	cmd.Flags().DurationVar(&player.MaxPause, "max-pause", 3*time.Second, "Longest pause between two steps, or 0 for no limit")
+	cmd.Flags().BoolVar(&player.Events, "events", false, "Show recorded events such as random draws alongside the game")
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(newReplayCmd())
+}
@@ -6,28 +6,58 @@
 package cmd
 
 import (
-	"os"
+	"fmt"
 
+	"github.com/chrisreddington/gh-game/internal/registry"
 	"github.com/chrisreddington/gh-game/internal/rockpaperscissors"
-	userPrompt "github.com/cli/go-gh/v2/pkg/prompter"
 	"github.com/spf13/cobra"
 )
 
-var secretMode bool
-
-// rootCmd represents the base command when called without any subcommands
-var rockPaperScissorsCmd = &cobra.Command{
-	Use:   "rockpaperscissors",
-	Short: "A simple Rock Paper Scissors game",
-	Long: `A simple Rock Paper Scissors game that allows you to play against the computer.
-You can choose from rock, paper, or scissors. The computer will randomly choose its move and the winner will be determined based on the rules of the game.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		input := userPrompt.New(os.Stdin, os.Stdout, os.Stderr)
-		rockpaperscissors.PlayGame(input, secretMode)
-	},
+// newRockPaperScissorsCmd creates the rockpaperscissors command
+func newRockPaperScissorsCmd() *cobra.Command {
+	var (
+		secretMode bool
+		rounds     int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rockpaperscissors",
+		Short: "A simple Rock Paper Scissors game",
+		Long: `A simple Rock Paper Scissors game that allows you to play against the computer.
+You can choose from rock, paper, or scissors. The computer will randomly choose its move and the winner will be determined based on the rules of the game.
+
+Example usage:
+  gh game rockpaperscissors
+  gh game rockpaperscissors --rounds 5 --spock`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if rounds < 0 || (rounds > 0 && rounds%2 == 0) {
+				return fmt.Errorf("rounds must be an odd number, got %d", rounds)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			session, err := newGameSession("rockpaperscissors")
+			if err != nil {
+				return err
+			}
+			return session.finish(rockpaperscissors.PlayGame(session.prompter, session.out, session.rng, rounds, secretMode))
+		},
+	}
+
+	cmd.Flags().BoolVar(&secretMode, "spock", false, "Enable secret game mode")
+	cmd.Flags().IntVar(&rounds, "rounds", 0, "Play a best of this many rounds (asks when not set)")
+
+	return cmd
 }
 
 func init() {
-	rockPaperScissorsCmd.Flags().BoolVar(&secretMode, "spock", false, "Enable secret game mode")
-	rootCmd.AddCommand(rockPaperScissorsCmd)
+	registry.Register(registry.Game{
+		Name:        "rockpaperscissors",
+		Description: "Play a best-of series of Rock Paper Scissors against the computer",
+		MinPlayers:  1,
+		MaxPlayers:  1,
+		Modes:       []string{"computer"},
+		HasAI:       true,
+		NewCommand:  newRockPaperScissorsCmd,
+	})
 }
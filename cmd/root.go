@@ -1,15 +1,82 @@
 package cmd
 
 import (
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chrisreddington/gh-game/internal/registry"
+	"github.com/chrisreddington/gh-game/internal/theme"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/muesli/termenv"
 	"github.com/spf13/cobra"
 )
 
+var (
+	// seed is the value used to seed every game's random source
+	seed int64
+	// jsonOutput switches every command to machine-readable output
+	jsonOutput bool
+	// themeName is the colour theme games are rendered with
+	themeName string
+)
+
 var rootCmd = &cobra.Command{
 	Use:   "gh-game",
 	Short: "A GitHub CLI extension for games",
 	Long:  `A GitHub CLI extension that allows you to play games through the GitHub CLI.`,
+	// main reports errors itself, and a failed game is not a usage mistake
+	SilenceErrors: true,
+	SilenceUsage:  true,
+}
+
+// prepare applies the settings shared by every command before it runs: the
+// configured defaults for global flags, then the colour theme.
+func prepare(cmd *cobra.Command, args []string) error {
+	if err := applyGlobalConfig(); err != nil {
+		return err
+	}
+
+	selected, err := theme.Resolve(themeName, rootCmd.PersistentFlags().Changed("theme"))
+	if err != nil {
+		return err
+	}
+	theme.Use(selected)
+
+	// lipgloss drops every style under NO_COLOR, but the monochrome theme
+	// only uses bold and underlined text, which NO_COLOR still allows
+	if selected == theme.Monochrome && term.FromEnv().IsTerminalOutput() {
+		lipgloss.SetColorProfile(termenv.ANSI)
+	}
+	return nil
+}
+
+// newRand returns the random source a game should draw from. When --seed is
+// given the source is deterministic, so a session can be replayed exactly.
+func newRand() *rand.Rand {
+	if !rootCmd.PersistentFlags().Changed("seed") {
+		seed = time.Now().UnixNano()
+	}
+	return rand.New(rand.NewSource(seed))
+}
+
+// addGameCommands adds a subcommand for every game in the registry.
+func addGameCommands() {
+	for _, game := range registry.Games() {
+		rootCmd.AddCommand(newGameCommand(game))
+	}
 }
 
 func Execute() error {
+	addGameCommands()
 	return rootCmd.Execute()
 }
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output JSON instead of styled text: games emit one event per line")
+	rootCmd.PersistentFlags().StringVar(&themeName, "theme", theme.DefaultName, "Colour theme: "+strings.Join(theme.Names(), ", "))
+	rootCmd.RegisterFlagCompletionFunc("theme", cobra.FixedCompletions(theme.Names(), cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.PersistentFlags().Int64Var(&seed, "seed", 0, "Seed for the random number generator, to replay a game exactly")
+	rootCmd.PersistentPreRunE = prepare
+}
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+
+	"github.com/chrisreddington/gh-game/internal/output"
+	"github.com/chrisreddington/gh-game/internal/record"
+	"github.com/chrisreddington/gh-game/internal/script"
+	"github.com/chrisreddington/gh-game/internal/stats"
+	userPrompt "github.com/cli/go-gh/v2/pkg/prompter"
+	"github.com/cli/go-gh/v2/pkg/term"
+)
+
+var (
+	// recordPath is where the session log is written when --record is given
+	recordPath string
+	// movesPath is the file scripted moves are read from, or - for stdin
+	movesPath string
+)
+
+// gameSession wires a game up to the prompter, output and random source
+// chosen by the global flags.
+type gameSession struct {
+	name     string
+	prompter record.Prompter
+	out      output.Output
+	rng      *rand.Rand
+	recorder *record.Recorder
+	script   *script.Prompter
+	prompts  *checkedPrompter
+	closers  []io.Closer
+}
+
+// newGameSession prepares a session of the named game. With --json the game
+// reports its events as JSON lines on stdout, and prompts move to stderr so
+// they do not mix with the events.
+func newGameSession(name string) (*gameSession, error) {
+	session := &gameSession{
+		name:     name,
+		prompter: userPrompt.New(os.Stdin, os.Stdout, os.Stderr),
+		out:      output.NewText(os.Stdout),
+		rng:      newRand(),
+	}
+	if jsonOutput {
+		session.prompter = userPrompt.New(os.Stdin, os.Stderr, os.Stderr)
+		session.out = output.NewJSON(os.Stdout)
+	}
+
+	if movesPath != "" {
+		if err := session.useScript(); err != nil {
+			return nil, err
+		}
+	} else if !term.IsTerminal(os.Stdin) {
+		session.prompter = noTerminal{}
+	}
+	session.prompts = &checkedPrompter{Prompter: session.prompter}
+	session.prompter = session.prompts
+
+	if recordPath != "" {
+		recorder, err := record.Create(recordPath, name, seed)
+		if err != nil {
+			session.close()
+			return nil, err
+		}
+		session.recorder = recorder
+		session.prompter = recorder.Prompter(session.prompter)
+		session.out = recorder.Output(session.out)
+	}
+
+	return session, nil
+}
+
+// useScript answers the game's prompts from the --moves script instead of the
+// terminal. Outside of JSON mode the prompts and answers are echoed so the
+// game reads the same as an interactive one.
+func (s *gameSession) useScript() error {
+	var moves io.Reader = os.Stdin
+	if movesPath != "-" {
+		file, err := os.Open(movesPath)
+		if err != nil {
+			return fmt.Errorf("opening moves: %w", err)
+		}
+		s.closers = append(s.closers, file)
+		moves = file
+	}
+
+	var echo io.Writer = os.Stdout
+	if jsonOutput {
+		echo = nil
+	}
+	s.script = script.New(moves, echo)
+	s.prompter = s.script
+	return nil
+}
+
+// errNoTerminal is returned by every prompt when there is no terminal to ask
+// on and no --moves script to answer from.
+var errNoTerminal = errors.New("cannot prompt for a move: stdin is not a terminal, pass the moves with --moves")
+
+// noTerminal is the prompter used when stdin is not a terminal. It fails
+// straight away instead of waiting on input that will never come.
+type noTerminal struct{}
+
+// Select implements record.Prompter.
+func (noTerminal) Select(prompt string, defaultValue string, options []string) (int, error) {
+	return -1, errNoTerminal
+}
+
+// Input implements record.Prompter.
+func (noTerminal) Input(prompt string, defaultValue string) (string, error) {
+	return "", errNoTerminal
+}
+
+// Confirm implements record.Prompter.
+func (noTerminal) Confirm(prompt string, defaultValue bool) (bool, error) {
+	return false, errNoTerminal
+}
+
+// checkedPrompter remembers the first prompt that failed, so the session can
+// report it once the game has given up.
+type checkedPrompter struct {
+	record.Prompter
+	err error
+}
+
+// Select implements record.Prompter.
+func (p *checkedPrompter) Select(prompt string, defaultValue string, options []string) (int, error) {
+	index, err := p.Prompter.Select(prompt, defaultValue, options)
+	return index, p.check(err)
+}
+
+// Input implements record.Prompter.
+func (p *checkedPrompter) Input(prompt string, defaultValue string) (string, error) {
+	answer, err := p.Prompter.Input(prompt, defaultValue)
+	return answer, p.check(err)
+}
+
+// Confirm implements record.Prompter.
+func (p *checkedPrompter) Confirm(prompt string, defaultValue bool) (bool, error) {
+	answer, err := p.Prompter.Confirm(prompt, defaultValue)
+	return answer, p.check(err)
+}
+
+// check keeps err if it is the first prompt error.
+func (p *checkedPrompter) check(err error) error {
+	if err != nil && p.err == nil {
+		p.err = err
+	}
+	return err
+}
+
+// close releases the files held open by the session.
+func (s *gameSession) close() {
+	for _, closer := range s.closers {
+		closer.Close()
+	}
+}
+
+// finish reports the outcome of the session, records it in the local
+// statistics and closes the session log. A game cut short by a failed prompt
+// reports the failure as an error event and makes the command fail, so a
+// script can tell it apart from a finished game.
+func (s *gameSession) finish(result stats.Result) error {
+	promptErr := s.prompts.err
+	if s.script != nil && s.script.Err() != nil {
+		promptErr = s.script.Err()
+	}
+	if promptErr != nil {
+		s.out.Event("error", output.Fields{"error": promptErr.Error()})
+	}
+	s.out.Event("result", output.Fields{
+		"played": result.Played,
+		"wins":   result.Wins,
+		"losses": result.Losses,
+		"draws":  result.Draws,
+		"streak": result.Streak,
+	})
+	recordStats(s.name, result)
+	defer s.close()
+
+	if s.recorder != nil {
+		if err := s.recorder.Close(); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Session recorded to %s\n", recordPath)
+	}
+	return promptErr
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&movesPath, "moves", "", "Answer prompts with the moves in `file`, one per line, or - to read them from stdin")
+	rootCmd.PersistentFlags().StringVar(&recordPath, "record", "", "Record the session to a JSON Lines `file` that can be played back with 'gh game replay'")
+}
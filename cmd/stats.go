@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/chrisreddington/gh-game/internal/registry"
+	"github.com/chrisreddington/gh-game/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+func newStatsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats [game]",
+		Short: "Show your game statistics",
+		Long: `Show the games played, wins, losses, draws and best streaks recorded
+on this machine. Pass a game name to see only that game.
+
+Statistics are stored in the gh-game directory inside the GitHub CLI
+config directory, which respects GH_CONFIG_DIR and XDG_CONFIG_HOME.
+
+Example usage:
+  gh game stats
+  gh game stats cointoss
+  gh game stats --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := stats.Load(stats.DefaultPath())
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 1 {
+				name := args[0]
+				if _, ok := registry.Lookup(name); !ok {
+					return fmt.Errorf("unknown game %q, run 'gh game list' to see the available games", name)
+				}
+				gameStats, _ := store.Get(name)
+				if jsonOutput {
+					return writeJSON(cmd.OutOrStdout(), gameStats)
+				}
+				return printGameStats(cmd.OutOrStdout(), name, gameStats)
+			}
+
+			if jsonOutput {
+				return writeJSON(cmd.OutOrStdout(), struct {
+					Games  map[string]*stats.GameStats `json:"games"`
+					Totals stats.GameStats             `json:"totals"`
+				}{store.Games, store.Totals()})
+			}
+			return printStatsTable(cmd.OutOrStdout(), store)
+		},
+	}
+
+	return cmd
+}
+
+// recordStats adds a finished session to the local statistics store.
+// Failures are reported but never interrupt the game.
+func recordStats(game string, result stats.Result) {
+	if result.Played == 0 {
+		return
+	}
+
+	store, err := stats.Load(stats.DefaultPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not load statistics: %v\n", err)
+		return
+	}
+	store.Record(game, result, time.Now())
+	if err := store.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Could not save statistics: %v\n", err)
+	}
+}
+
+// printGameStats writes the statistics of a single game as a list of totals and personal bests.
+func printGameStats(w io.Writer, name string, gameStats stats.GameStats) error {
+	if gameStats.Played == 0 {
+		_, err := fmt.Fprintf(w, "No %s games recorded yet.\n", name)
+		return err
+	}
+
+	fmt.Fprintf(w, "%s\n\n", name)
+	fmt.Fprintf(w, "Played:       %d\n", gameStats.Played)
+	fmt.Fprintf(w, "Wins:         %d\n", gameStats.Wins)
+	fmt.Fprintf(w, "Losses:       %d\n", gameStats.Losses)
+	fmt.Fprintf(w, "Draws:        %d\n", gameStats.Draws)
+	fmt.Fprintf(w, "Best streak:  %d", gameStats.BestStreak)
+	if !gameStats.BestStreakAt.IsZero() {
+		fmt.Fprintf(w, " (%s)", formatTime(gameStats.BestStreakAt))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "First played: %s\n", formatTime(gameStats.FirstPlayed))
+	_, err := fmt.Fprintf(w, "Last played:  %s\n", formatTime(gameStats.LastPlayed))
+	return err
+}
+
+// printStatsTable writes the statistics of every game as a table with a totals row.
+func printStatsTable(w io.Writer, store *stats.Store) error {
+	names := store.Names()
+	if len(names) == 0 {
+		_, err := fmt.Fprintln(w, "No games recorded yet. Play a game to start tracking your statistics!")
+		return err
+	}
+
+	table := newTablePrinter(w)
+	table.AddHeader([]string{"GAME", "PLAYED", "WINS", "LOSSES", "DRAWS", "BEST STREAK", "LAST PLAYED"})
+	addRow := func(name string, gameStats stats.GameStats) {
+		table.AddField(name)
+		table.AddField(fmt.Sprintf("%d", gameStats.Played))
+		table.AddField(fmt.Sprintf("%d", gameStats.Wins))
+		table.AddField(fmt.Sprintf("%d", gameStats.Losses))
+		table.AddField(fmt.Sprintf("%d", gameStats.Draws))
+		table.AddField(fmt.Sprintf("%d", gameStats.BestStreak))
+		table.AddField(formatTime(gameStats.LastPlayed))
+		table.EndRow()
+	}
+
+	for _, name := range names {
+		gameStats, _ := store.Get(name)
+		addRow(name, gameStats)
+	}
+	addRow("total", store.Totals())
+
+	return table.Render()
+}
+
+// formatTime renders a timestamp in the local time zone.
+func formatTime(t time.Time) string {
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func init() {
+	rootCmd.AddCommand(newStatsCmd())
+}
@@ -1,104 +1,500 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"io"
+	"slices"
+	"strings"
+	"time"
 
-	"github.com/charmbracelet/lipgloss"
+	"github.com/chrisreddington/gh-game/internal/output"
+	"github.com/chrisreddington/gh-game/internal/registry"
+	"github.com/chrisreddington/gh-game/internal/stats"
 	"github.com/chrisreddington/gh-game/internal/tictactoe"
-	userPrompt "github.com/cli/go-gh/v2/pkg/prompter"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
-var (
-	xStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("45"))  // bright blue
-	oStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("226")) // bright yellow
-)
+// tictactoeFirst lists the --first values
+var tictactoeFirst = []string{"human", "computer", "random"}
+
+// tictactoeVariants lists the --variant values
+var tictactoeVariants = []string{"standard", "ultimate", "misere", "wild", "notakto", "quantum"}
+
+// tictactoeRules maps the --variant values played on a single board of any
+// size to their rules
+var tictactoeRules = map[string]tictactoe.Variant{
+	"standard": tictactoe.Standard,
+	"misere":   tictactoe.Misere,
+	"wild":     tictactoe.Wild,
+}
+
+// tictactoeModes maps the --mode values to game modes. In cvc mode the
+// computer plays itself, so nobody has a side of their own and the game is
+// played as a local one.
+var tictactoeModes = map[string]tictactoe.GameMode{
+	"local":    tictactoe.LocalGame,
+	"computer": tictactoe.ComputerGame,
+	"cvc":      tictactoe.LocalGame,
+}
+
+// tictactoeModeNames lists the --mode values in the order they are offered
+var tictactoeModeNames = []string{"local", "computer", "cvc"}
+
+// tictactoeOptions holds the flags of the tictactoe command
+type tictactoeOptions struct {
+	mode       string
+	variant    string
+	difficulty string
+	mark       string
+	first      string
+	size       int
+	win        int
+	boards     int
+	export     bool
+	noTakeback bool
+	xLevel     string
+	oLevel     string
+	delay      time.Duration
+	games      int
+}
+
+// validate checks the flags, once any configured values have been applied.
+// Options that only suit some variants are rejected for the others when they
+// are given on the command line, and a configured value is ignored.
+func (o *tictactoeOptions) validate(flags *pflag.FlagSet) error {
+	if _, ok := tictactoeModes[o.mode]; o.mode != "" && !ok {
+		return fmt.Errorf("invalid mode %q: must be local, computer or cvc", o.mode)
+	}
+	if o.games < 1 {
+		return fmt.Errorf("invalid games %d: must be at least 1", o.games)
+	}
+	if o.mode != "cvc" && flags.Changed("games") && o.games > 1 {
+		return errors.New("--games can only be used with --mode cvc")
+	}
+	if o.mode == "cvc" && o.games > 1 && o.export {
+		return errors.New("--export can only be used for a single game")
+	}
+	if o.delay < 0 {
+		return fmt.Errorf("invalid delay %s: must not be negative", o.delay)
+	}
+	for _, level := range []string{o.xLevel, o.oLevel} {
+		if _, err := tictactoe.ParseDifficulty(level); level != "" && err != nil {
+			return err
+		}
+	}
+	if !slices.Contains(tictactoeVariants, o.variant) {
+		return fmt.Errorf("invalid variant %q: must be one of %s", o.variant, strings.Join(tictactoeVariants, ", "))
+	}
+	if _, ok := tictactoeRules[o.variant]; !ok && (flags.Changed("size") || flags.Changed("win")) {
+		return errors.New("--size and --win can only be used with the standard, misere and wild variants")
+	}
+	if o.variant != "notakto" && flags.Changed("boards") {
+		return errors.New("--boards can only be used with the notakto variant")
+	}
+	if o.boards < 1 || o.boards > tictactoe.MaxNotaktoBoards {
+		return fmt.Errorf("invalid boards %d: must be between 1 and %d", o.boards, tictactoe.MaxNotaktoBoards)
+	}
+	if o.variant != "standard" && o.export {
+		return errors.New("--export can only be used with the standard variant")
+	}
+	if o.mark != "x" && o.mark != "o" {
+		return fmt.Errorf("invalid mark %q: must be x or o", o.mark)
+	}
+	if !slices.Contains(tictactoeFirst, o.first) {
+		return fmt.Errorf("invalid first %q: must be human, computer or random", o.first)
+	}
+	if _, err := tictactoe.NewSizedGame(tictactoe.LocalGame, o.size, o.winLength(), nil); err != nil {
+		return err
+	}
+	_, err := tictactoe.ParseDifficulty(o.difficulty)
+	return err
+}
+
+// sideDifficulty returns how well the computer plays mark in cvc mode: the
+// difficulty given for that side, or --difficulty.
+func (o *tictactoeOptions) sideDifficulty(mark string) tictactoe.Difficulty {
+	level := o.xLevel
+	if mark == "O" {
+		level = o.oLevel
+	}
+	if level == "" {
+		level = o.difficulty
+	}
+	difficulty, _ := tictactoe.ParseDifficulty(level)
+	return difficulty
+}
+
+// winLength returns how many marks in a row win. Unless --win is given that
+// is the whole row, up to five in a row on big boards.
+func (o *tictactoeOptions) winLength() int {
+	if o.win > 0 {
+		return o.win
+	}
+	return tictactoe.DefaultWinLength(o.size)
+}
+
+func newTictactoeCmd() *cobra.Command {
+	var opts tictactoeOptions
 
-var tictactoeCmd = &cobra.Command{
-	Use:   "tictactoe",
-	Short: "Play Tic-tac-toe",
-	Long: `Start a game of Tic-tac-toe where you can play against another player locally or against the computer.
+	cmd := &cobra.Command{
+		Use:   "tictactoe",
+		Short: "Play Tic-tac-toe",
+		Long: `Start a game of Tic-tac-toe where you can play against another player locally or against the computer.
 Choose between two game modes:
 - Local Multiplayer: Play against another player on the same computer
-- Play Against Computer: Play against an AI opponent that uses basic strategy`,
-	Run: func(cmd *cobra.Command, args []string) {
-		prompter := userPrompt.New(os.Stdin, os.Stdout, os.Stderr)
+- Play Against Computer: Play against an AI opponent
 
+The computer searches the game tree for its best move. Choose how well it
+plays with --difficulty: easy, medium and hard make deliberate mistakes, and
+perfect never loses. Against the computer you play X and move first unless
+you choose otherwise with --mark and --first.
+
+On your turn you can choose Undo to take your last move back, or Redo to play
+it again. Against the computer, Undo also takes back the computer's reply.
+Turn takebacks off with --no-takebacks, such as for a fair game between two
+players.
+
+Watch the computer play itself with --mode cvc. Set how well each side plays
+with --x-difficulty and --o-difficulty, and pause between moves with --delay.
+With --games the games are not shown, only how many each side won.
+
+Play on a bigger board with --size, and set how many marks in a row win with
+--win. Squares on boards bigger than 3x3 are named by a column letter and a
+row number, such as c7.
+
+Play ultimate tic-tac-toe with --variant ultimate: nine small boards in a 3x3
+grid, where winning a small board claims its square on the big board and
+three claimed squares in a row win. Your move sends your opponent to the small
+board in the same place as the square you played.
+
+Other variants change who wins:
+- misere: completing a line loses
+- wild: on each move you place X or O, and whoever completes a line of either wins
+- notakto: both players place X, a board with three in a row is dead, and
+  whoever kills the last board loses. Play on up to three boards with --boards.
+
+Play quantum tic-tac-toe with --variant quantum. Each move places a spooky
+mark in two squares at once, chosen one after the other. When marks form a
+cycle, the player who did not close it chooses which of the two squares the
+last mark collapses into, and every mark entangled with it collapses too.
+Three collapsed marks in a row win; if a collapse gives both players a line,
+the line finished first scores 1 and the other ½.
+
+Example usage:
+  gh game tictactoe
+  gh game tictactoe --mode computer
+  gh game tictactoe --mode computer --difficulty perfect
+  gh game tictactoe --mode computer --mark o --first computer
+  gh game tictactoe --mode local --no-takebacks
+  gh game tictactoe --mode cvc --delay 1s
+  gh game tictactoe --mode cvc --x-difficulty easy --o-difficulty perfect --games 100
+  gh game tictactoe --size 4 --win 4
+  gh game tictactoe --mode computer --size 15 --win 5
+  gh game tictactoe --variant ultimate --mode computer
+  gh game tictactoe --variant misere --mode computer
+  gh game tictactoe --variant wild
+  gh game tictactoe --variant notakto --boards 3 --mode computer
+  gh game tictactoe --variant quantum --mode computer`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.validate(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			session, err := newGameSession("tictactoe")
+			if err != nil {
+				return err
+			}
+			result, err := playTictactoe(session, opts)
+			if finishErr := session.finish(result); err == nil {
+				err = finishErr
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.mode, "mode", "", "Game mode: local, computer or cvc (asks when not set)")
+	cmd.RegisterFlagCompletionFunc("mode", cobra.FixedCompletions(tictactoeModeNames, cobra.ShellCompDirectiveNoFileComp))
+	cmd.Flags().StringVar(&opts.variant, "variant", "standard", "Rules to play: "+strings.Join(tictactoeVariants, ", "))
+	cmd.RegisterFlagCompletionFunc("variant", cobra.FixedCompletions(tictactoeVariants, cobra.ShellCompDirectiveNoFileComp))
+	cmd.Flags().StringVar(&opts.difficulty, "difficulty", tictactoe.Hard.String(), "How well the computer plays: "+strings.Join(tictactoe.DifficultyNames(), ", "))
+	cmd.RegisterFlagCompletionFunc("difficulty", cobra.FixedCompletions(tictactoe.DifficultyNames(), cobra.ShellCompDirectiveNoFileComp))
+	cmd.Flags().StringVar(&opts.mark, "mark", "x", "Mark you play against the computer: x or o")
+	cmd.RegisterFlagCompletionFunc("mark", cobra.FixedCompletions([]string{"x", "o"}, cobra.ShellCompDirectiveNoFileComp))
+	cmd.Flags().StringVar(&opts.first, "first", "human", "Who moves first against the computer: human, computer or random")
+	cmd.RegisterFlagCompletionFunc("first", cobra.FixedCompletions(tictactoeFirst, cobra.ShellCompDirectiveNoFileComp))
+	cmd.Flags().IntVar(&opts.size, "size", tictactoe.MinSize, fmt.Sprintf("Number of rows and columns on the board, from %d to %d", tictactoe.MinSize, tictactoe.MaxSize))
+	cmd.Flags().IntVar(&opts.win, "win", 0, "Marks in a row needed to win (default the board size, up to 5)")
+	cmd.Flags().IntVar(&opts.boards, "boards", 1, fmt.Sprintf("Number of boards in Notakto, from 1 to %d", tictactoe.MaxNotaktoBoards))
+	cmd.Flags().StringVar(&opts.xLevel, "x-difficulty", "", "How well the computer plays X in cvc mode (default --difficulty)")
+	cmd.RegisterFlagCompletionFunc("x-difficulty", cobra.FixedCompletions(tictactoe.DifficultyNames(), cobra.ShellCompDirectiveNoFileComp))
+	cmd.Flags().StringVar(&opts.oLevel, "o-difficulty", "", "How well the computer plays O in cvc mode (default --difficulty)")
+	cmd.RegisterFlagCompletionFunc("o-difficulty", cobra.FixedCompletions(tictactoe.DifficultyNames(), cobra.ShellCompDirectiveNoFileComp))
+	cmd.Flags().DurationVar(&opts.delay, "delay", 0, "Pause before each computer move in cvc mode, such as 500ms")
+	cmd.Flags().IntVar(&opts.games, "games", 1, "Number of games to play in cvc mode, showing only the totals when more than one")
+	cmd.Flags().BoolVar(&opts.noTakeback, "no-takebacks", false, "Stop players undoing and redoing their moves")
+	cmd.Flags().BoolVar(&opts.export, "export", false, "Print the moves and final board in notation when the game ends")
+
+	cmd.AddCommand(newTictactoeAnalyzeCmd())
+
+	return cmd
+}
+
+// playTictactoe runs the tic-tac-toe game loop and returns the outcome, or an
+// error if the game could not be set up or played to the end. The player is
+// asked for the game mode unless the options name one.
+func playTictactoe(session *gameSession, opts tictactoeOptions) (stats.Result, error) {
+	mode, ok := tictactoeModes[opts.mode]
+	if !ok {
 		// Select game mode
-		modeIndex, err := prompter.Select(
+		modeIndex, err := session.prompter.Select(
 			"Select game mode:",
 			"Local Multiplayer",
-			[]string{"Local Multiplayer", "Play Against Computer"},
+			[]string{"Local Multiplayer", "Play Against Computer", "Watch Computer vs Computer"},
 		)
 		if err != nil {
-			fmt.Printf("Error selecting game mode: %v\n", err)
-			return
+			return stats.Result{}, fmt.Errorf("selecting game mode: %w", err)
+		}
+
+		opts.mode = tictactoeModeNames[0] // Default value
+		if modeIndex >= 0 && modeIndex < len(tictactoeModeNames) {
+			opts.mode = tictactoeModeNames[modeIndex]
+		}
+		mode = tictactoeModes[opts.mode]
+	}
+	if opts.mode == "cvc" {
+		return watchTictactoe(session, opts)
+	}
+	game, err := newTictactoeGame(mode, opts, session)
+	if err != nil {
+		return stats.Result{}, err
+	}
+	if mode == tictactoe.ComputerGame {
+		humanFirst := opts.first == "human" || (opts.first == "random" && session.rng.Intn(2) == 0)
+		if err := game.ChooseSides(strings.ToUpper(opts.mark), humanFirst); err != nil {
+			return stats.Result{}, err
+		}
+	}
+
+	difficulty, _ := tictactoe.ParseDifficulty(opts.difficulty)
+	result, err := runTictactoe(session, game, difficulty)
+	if err == nil && opts.export {
+		exportTictactoe(session.out, game)
+	}
+	return result, err
+}
+
+// watchTictactoe plays the computer against itself. A single game is shown
+// move by move, pausing for --delay before each move. With --games the games
+// are played without being shown, and only the totals are. The computer's
+// games are not counted in the player's statistics.
+func watchTictactoe(session *gameSession, opts tictactoeOptions) (stats.Result, error) {
+	out := session.out
+	x := tictactoe.Player(tictactoe.ComputerPlayer{Difficulty: opts.sideDifficulty("X")})
+	o := tictactoe.Player(tictactoe.ComputerPlayer{Difficulty: opts.sideDifficulty("O")})
+
+	if opts.games == 1 {
+		if opts.delay > 0 {
+			x = tictactoe.DelayedPlayer{Player: x, Delay: opts.delay}
+			o = tictactoe.DelayedPlayer{Player: o, Delay: opts.delay}
+		}
+		game, err := newTictactoeGame(tictactoe.LocalGame, opts, session)
+		if err != nil {
+			return stats.Result{}, err
+		}
+		if _, err := tictactoe.NewSession(game, x, o, out).Play(); err != nil {
+			return stats.Result{}, err
+		}
+		if opts.export {
+			exportTictactoe(out, game)
 		}
+		return stats.Result{}, nil
+	}
 
-		// Initialize game with selected mode
-		mode := tictactoe.LocalGame
-		if modeIndex == 1 {
-			mode = tictactoe.ComputerGame
+	wins := map[string]int{}
+	for number := 1; number <= opts.games; number++ {
+		game, err := newTictactoeGame(tictactoe.LocalGame, opts, session)
+		if err != nil {
+			return stats.Result{}, err
+		}
+		winner, err := tictactoe.NewSession(game, x, o, output.Discard).Play()
+		if err != nil {
+			return stats.Result{}, fmt.Errorf("playing game %d: %w", number, err)
 		}
-		game := tictactoe.NewGame(mode)
-
-		// Main game loop
-		for {
-			fmt.Println(game)
-			currentMark := game.CurrentPlayer
-			style := xStyle
-			if currentMark == "O" {
-				style = oStyle
+		wins[winner]++
+		out.Event("game", output.Fields{"number": number, "winner": winner})
+	}
+
+	out.Event("summary", output.Fields{
+		"games":        opts.games,
+		"x_difficulty": opts.sideDifficulty("X").String(),
+		"o_difficulty": opts.sideDifficulty("O").String(),
+		"x_wins":       wins["X"],
+		"o_wins":       wins["O"],
+		"draws":        wins[""],
+	})
+	out.Printf("Played %d games\n", opts.games)
+	out.Printf("X (%s) won %s\n", opts.sideDifficulty("X"), percentOf(wins["X"], opts.games))
+	out.Printf("O (%s) won %s\n", opts.sideDifficulty("O"), percentOf(wins["O"], opts.games))
+	out.Printf("Drawn %s\n", percentOf(wins[""], opts.games))
+	return stats.Result{}, nil
+}
+
+// percentOf formats count as a number and a percentage of total, such as
+// "12 (24%)".
+func percentOf(count, total int) string {
+	return fmt.Sprintf("%d (%d%%)", count, count*100/total)
+}
+
+// runTictactoe plays the game between the human and the computer, or two
+// humans in a local game, and returns the outcome, or an error if a player
+// could not move.
+func runTictactoe(session *gameSession, game tictactoe.Playable, difficulty tictactoe.Difficulty) (stats.Result, error) {
+	human := tictactoe.HumanPlayer{Prompter: session.prompter}
+	players := map[string]tictactoe.Player{"X": human, "O": human}
+	if computer := game.Computer(); computer != "" {
+		players[computer] = tictactoe.ComputerPlayer{Difficulty: difficulty}
+	}
+
+	winner, err := tictactoe.NewSession(game, players["X"], players["O"], session.out).Play()
+	if err != nil {
+		return stats.Result{}, err
+	}
+	return tictactoeResult(game, winner), nil
+}
+
+// exportTictactoe shows a finished game's moves and final board in notation,
+// so it can be shared or loaded into analyze. Games cut short, and variants
+// without a notation, are not exported.
+func exportTictactoe(out output.Output, playable tictactoe.Playable) {
+	game, ok := playable.(*tictactoe.Game)
+	if !ok || (game.GetWinner() == "" && !game.IsBoardFull()) {
+		return
+	}
+	out.Event("export", output.Fields{
+		"moves": game.Notation(),
+		"board": game.BoardString(),
+	})
+	out.Printf("Moves: %s\n", game.Notation())
+	out.Printf("Board: %s\n", game.BoardString())
+}
+
+// newTictactoeAnalyzeCmd creates the command that analyzes a position.
+func newTictactoeAnalyzeCmd() *cobra.Command {
+	var size, win int
+
+	cmd := &cobra.Command{
+		Use:   "analyze <position>",
+		Short: "Show the best moves in a Tic-tac-toe position",
+		Long: `Analyze a Tic-tac-toe position: whose turn it is, who wins with best play
+and which moves keep that result.
+
+Give the position as a board string, one character per square row by row
+with X, O and . for an empty square, or as the moves that led to it, such as
+"X5 O1 X9". The board size follows from a board string; moves are played on
+a 3x3 board unless --size says otherwise. Larger boards are only searched a
+few moves ahead, so their value is unclear unless a forced win is found.
+
+Example usage:
+  gh game tictactoe analyze X.O......
+  gh game tictactoe analyze "X5 O1 X9"
+  gh game tictactoe analyze "Xh8 Oh9 Xg7" --size 15 --win 5
+  gh game tictactoe analyze X.O...... --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			game, err := tictactoe.ParseGame(args[0], size, win)
+			if err != nil {
+				return err
 			}
-			fmt.Printf("Player %s's turn\n", style.Render(currentMark))
-
-			// Get move from either computer or human player
-			var rowIndex, columnIndex int
-			if game.IsComputerTurn() {
-				rowIndex, columnIndex = game.GetComputerMove()
-				position := rowIndex*3 + columnIndex + 1
-				fmt.Printf("Computer places %s at position %d\n", oStyle.Render("O"), position)
-			} else {
-				var err error
-				rowIndex, columnIndex, err = tictactoe.GetPlayerMove(prompter, game)
-				if err != nil {
-					fmt.Printf("Error getting move: %v\n", err)
-					return
-				}
+			analysis := game.Analyze()
+			if jsonOutput {
+				return writeJSON(cmd.OutOrStdout(), analysis)
 			}
+			printTictactoeAnalysis(cmd.OutOrStdout(), game, analysis)
+			return nil
+		},
+	}
 
-			// Apply the move
-			if err := game.MakeMove(rowIndex, columnIndex); err != nil {
-				fmt.Printf("Invalid move: %v\n", err)
-				continue
-			}
+	cmd.Flags().IntVar(&size, "size", 0, "Number of rows and columns for a position given as moves (default 3)")
+	cmd.Flags().IntVar(&win, "win", 0, "Marks in a row needed to win (default the board size, up to 5)")
 
-			// Check win condition
-			if winner := game.GetWinner(); winner != "" {
-				fmt.Println(game)
-				style := xStyle
-				if winner == "O" {
-					style = oStyle
-				}
-				if game.Mode == tictactoe.ComputerGame && winner == game.ComputerMark {
-					fmt.Printf("Computer (%s) wins!\n", style.Render(winner))
-				} else {
-					fmt.Printf("Player %s wins!\n", style.Render(winner))
-				}
-				return
-			}
+	return cmd
+}
 
-			// Check draw condition
-			if game.IsBoardFull() {
-				fmt.Println(game)
-				fmt.Println("It's a draw!")
-				return
-			}
+// printTictactoeAnalysis writes the board followed by the analysis.
+func printTictactoeAnalysis(w io.Writer, game *tictactoe.Game, analysis tictactoe.Analysis) {
+	fmt.Fprintln(w, game)
+	if analysis.Turn == "" {
+		fmt.Fprintln(w, "Turn: none, the game is over")
+	} else {
+		fmt.Fprintf(w, "Turn: %s\n", tictactoe.RenderMark(analysis.Turn))
+	}
+	fmt.Fprintf(w, "Value: %s\n", analysis.Value)
+	if len(analysis.BestMoves) > 0 {
+		fmt.Fprintf(w, "Best moves: %s\n", strings.Join(analysis.BestMoves, ", "))
+	}
+}
+
+// newTictactoeGame creates a game of the variant the options name, with the
+// computer playing at the chosen difficulty.
+func newTictactoeGame(mode tictactoe.GameMode, opts tictactoeOptions, session *gameSession) (tictactoe.Playable, error) {
+	difficulty, err := tictactoe.ParseDifficulty(opts.difficulty)
+	if err != nil {
+		return nil, err
+	}
+	switch opts.variant {
+	case "ultimate":
+		game := tictactoe.NewUltimateGame(mode, session.rng)
+		game.Difficulty = difficulty
+		return game, nil
+	case "quantum":
+		game := tictactoe.NewQuantumGame(mode, session.rng)
+		game.Difficulty = difficulty
+		return game, nil
+	case "notakto":
+		game, err := tictactoe.NewNotaktoGame(mode, opts.boards, session.rng)
+		if err != nil {
+			return nil, err
 		}
-	},
+		game.Difficulty = difficulty
+		return game, nil
+	}
+	game, err := tictactoe.NewSizedGame(mode, opts.size, opts.winLength(), session.rng)
+	if err != nil {
+		return nil, err
+	}
+	game.Variant = tictactoeRules[opts.variant]
+	game.Difficulty = difficulty
+	game.Takebacks = !opts.noTakeback
+	return game, nil
+}
+
+// tictactoeResult converts the end of a game into statistics. Wins and losses
+// are only counted against the computer, since a local game has no single
+// player whose record it belongs to.
+func tictactoeResult(game tictactoe.Playable, winner string) stats.Result {
+	result := stats.Result{Played: 1}
+	switch {
+	case winner == "":
+		result.Draws = 1
+	case game.Computer() == "":
+	case winner == game.Computer():
+		result.Losses = 1
+	default:
+		result.Wins = 1
+		result.Streak = 1
+	}
+	return result
 }
 
 func init() {
-	rootCmd.AddCommand(tictactoeCmd)
+	registry.Register(registry.Game{
+		Name:        "tictactoe",
+		Description: "Play Tic-tac-toe against a friend or the computer",
+		MinPlayers:  1,
+		MaxPlayers:  2,
+		Modes:       tictactoeModeNames,
+		HasAI:       true,
+		NewCommand:  newTictactoeCmd,
+	})
 }
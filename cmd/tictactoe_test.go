@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chrisreddington/gh-game/internal/registry"
+)
+
+func TestTictactoe_ConfiguredVariantOptions(t *testing.T) {
+	cfg := useConfig(t, map[string]string{"tictactoe.size": "5", "tictactoe.win": "4", "tictactoe.boards": "3"})
+	game, _ := registry.Lookup("tictactoe")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"configured size is ignored", []string{"--variant", "ultimate"}, ""},
+		{"configured boards are ignored", []string{"--variant", "misere"}, ""},
+		{"configured boards are used", []string{"--variant", "notakto"}, ""},
+		{"size flag is rejected", []string{"--variant", "quantum", "--size", "4"}, "--size and --win can only be used"},
+		{"win flag is rejected", []string{"--variant", "notakto", "--win", "3"}, "--size and --win can only be used"},
+		{"boards flag is rejected", []string{"--boards", "2"}, "--boards can only be used"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newGameCommand(game)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			err := cmd.PreRunE(cmd, nil)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("PreRunE() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("PreRunE() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := validateGameConfig(cfg, game.NewCommand(), "tictactoe.variant", "ultimate"); err != nil {
+		t.Errorf("setting tictactoe.variant to ultimate unexpected error: %v", err)
+	}
+}
+
+func TestTictactoe_ConfiguredGames(t *testing.T) {
+	useConfig(t, map[string]string{"tictactoe.games": "10"})
+	game, _ := registry.Lookup("tictactoe")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"configured games are ignored", []string{"--mode", "computer"}, ""},
+		{"export outside cvc mode", []string{"--mode", "computer", "--export"}, ""},
+		{"configured games are used", []string{"--mode", "cvc"}, ""},
+		{"games flag is rejected", []string{"--mode", "local", "--games", "5"}, "--games can only be used with --mode cvc"},
+		{"export of many games", []string{"--mode", "cvc", "--export"}, "--export can only be used for a single game"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newGameCommand(game)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			err := cmd.PreRunE(cmd, nil)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("PreRunE() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("PreRunE() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
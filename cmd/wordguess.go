@@ -1,31 +1,206 @@
 package cmd
 
 import (
-	"os"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/chrisreddington/gh-game/internal/registry"
 	"github.com/chrisreddington/gh-game/internal/wordguess"
-	userPrompt "github.com/cli/go-gh/v2/pkg/prompter"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
-var wordguessCmd = &cobra.Command{
-	Use:   "wordguess",
-	Short: "Play Word Guess",
-	Long: `Start a game of Word Guess where you guess a GitHub-related term one letter at a time.
-	
+// wordguessModes lists the --mode values: guessing letters, or whole words as
+// in Wordle
+var wordguessModes = []string{"classic", "wordle"}
+
+// wordguessOptions holds the flags of the wordguess command
+type wordguessOptions struct {
+	mode         string
+	hard         bool
+	evil         bool
+	maxIncorrect int
+	wordlist     string
+	category     string
+	fromRepo     bool
+}
+
+// validate checks the flags, once any configured values have been applied.
+// Options that only suit one mode are rejected in the other when they are
+// given on the command line, and a configured value is ignored.
+func (o *wordguessOptions) validate(flags *pflag.FlagSet) error {
+	if !slices.Contains(wordguessModes, o.mode) {
+		return fmt.Errorf("invalid mode %q: must be classic or wordle", o.mode)
+	}
+	if o.maxIncorrect < 1 {
+		return fmt.Errorf("max-incorrect must be at least 1")
+	}
+	if o.mode != "wordle" && flags.Changed("hard") {
+		return errors.New("--hard can only be used with --mode wordle")
+	}
+	if o.mode != "classic" && flags.Changed("evil") {
+		return errors.New("--evil can only be used with --mode classic")
+	}
+	if o.mode == "wordle" && flags.Changed("max-incorrect") {
+		return fmt.Errorf("--max-incorrect cannot be used with --mode wordle, which always allows %d guesses", wordguess.WordleAttempts)
+	}
+	sources := 0
+	for _, set := range []bool{o.wordlist != "", o.category != "", o.fromRepo} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return errors.New("only one of --wordlist, --category and --from-repo can be used")
+	}
+	words, err := o.words()
+	if err == nil && o.mode == "wordle" && words != nil {
+		_, err = wordguess.WordleAnswers(words)
+	}
+	return err
+}
+
+// words returns the word list the options choose: the words in --wordlist,
+// the --category words, or nil for the default list. Words harvested with
+// --from-repo are left to repoWords, as they depend on where the game is run.
+func (o *wordguessOptions) words() ([]string, error) {
+	switch {
+	case o.wordlist != "":
+		return wordguess.LoadWordList(o.wordlist)
+	case o.category != "":
+		return wordguess.CategoryWords(o.category)
+	default:
+		return nil, nil
+	}
+}
+
+// repoWords harvests the words of the git repository in the current
+// directory.
+// Returns an error if it is not in a repository or no words were found.
+func repoWords() (*wordguess.WordPool, error) {
+	repo, err := wordguess.OpenRepo(".")
+	if err != nil {
+		return nil, err
+	}
+	pool := repo.Harvest()
+	if len(pool.Words) == 0 {
+		return nil, errors.New("no words found in this repository")
+	}
+	return pool, nil
+}
+
+func newWordguessCmd() *cobra.Command {
+	var opts wordguessOptions
+
+	cmd := &cobra.Command{
+		Use:   "wordguess",
+		Short: "Play Word Guess",
+		Long: `Start a game of Word Guess where you guess a GitHub-related term one letter at a time.
+
 The rules are simple:
 1. A random word will be selected
 2. Guess one letter at a time
 3. If the letter is in the word, it will be revealed
 4. If not, you lose one of your available guesses
 5. You win by guessing the word before running out of guesses
-6. You lose if you make 6 incorrect guesses`,
-	Run: func(cmd *cobra.Command, args []string) {
-		input := userPrompt.New(os.Stdin, os.Stdout, os.Stderr)
-		wordguess.PlayGame(input)
-	},
+6. You lose if you make 6 incorrect guesses (change this with --max-incorrect)
+
+Pick words from a bundled category with --category, or from your own list
+with --wordlist: a text file with one word per line, where blank lines and
+lines starting with # are skipped. Words may only contain letters, and
+repeated words are only counted once.
+
+Words need not be English: the german, french and japanese categories are
+bundled, and the letters offered follow the language of the list. Accented
+letters can be typed composed or with combining accents, and Japanese kana in
+hiragana or katakana.
+
+With --from-repo, words come from the git repository you are in: identifiers
+in source files, README files, markdown headings, branch names and commit
+messages. Only words of 5 to 12 letters found at least twice are used, and
+once each word is over you are shown where it came from.
+
+With --evil the game cheats: it never settles on a word, but keeps every word
+of the list that fits what you have seen so far. After each guess it keeps the
+largest group of those words that have the letter in the same places, which
+is often none of them, so you have to squeeze the word into a corner.
+
+With --mode wordle you guess whole five-letter words instead, in six tries.
+Each letter of a guess is marked green if it is in the right place, yellow if
+it is elsewhere in the word and grey if it is not in it; a letter guessed more
+times than it is in the word is only marked as often as it is there. Guesses
+must be words in the dictionary or the word list. With --hard, green letters
+must stay in place and yellow letters must be used in every later guess. At
+the end you get a grid of emoji squares to share in team chat.
+
+Example usage:
+  gh game wordguess
+  gh game wordguess --max-incorrect 10
+  gh game wordguess --category git
+  gh game wordguess --category japanese
+  gh game wordguess --wordlist team-jargon.txt
+  gh game wordguess --from-repo
+  gh game wordguess --evil --category git
+  gh game wordguess --mode wordle --hard`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.validate(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			gameOpts := wordguess.Options{MaxIncorrect: opts.maxIncorrect, Evil: opts.evil}
+			if opts.fromRepo {
+				pool, err := repoWords()
+				if err != nil {
+					return err
+				}
+				gameOpts.Words, gameOpts.Origins = pool.Words, pool.Origins
+				gameOpts.Topic = "word from this repository"
+			} else {
+				words, err := opts.words()
+				if err != nil {
+					return err
+				}
+				gameOpts.Words = words
+			}
+			play := wordguess.PlayGame
+			if opts.mode == "wordle" {
+				answers, err := wordguess.WordleAnswers(gameOpts.Words)
+				if err != nil {
+					return err
+				}
+				gameOpts.Words, gameOpts.HardMode = answers, opts.hard
+				play = wordguess.PlayWordle
+			}
+			session, err := newGameSession("wordguess")
+			if err != nil {
+				return err
+			}
+			return session.finish(play(session.prompter, session.out, session.rng, gameOpts))
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.mode, "mode", "classic", "Game mode: classic to guess letters, or wordle to guess five-letter words")
+	cmd.Flags().BoolVar(&opts.hard, "hard", false, "In wordle mode, make every hint revealed a must in later guesses")
+	cmd.Flags().BoolVar(&opts.evil, "evil", false, "Keep changing the word to dodge your guesses")
+	cmd.Flags().IntVar(&opts.maxIncorrect, "max-incorrect", wordguess.MaxIncorrectGuesses, "Number of incorrect guesses allowed before losing")
+	cmd.Flags().StringVar(&opts.wordlist, "wordlist", "", "File of words to guess, one per line")
+	cmd.Flags().StringVar(&opts.category, "category", "", "Bundled word list to guess from: "+strings.Join(wordguess.Categories(), ", "))
+	cmd.Flags().BoolVar(&opts.fromRepo, "from-repo", false, "Guess words found in the git repository you are in")
+	cmd.RegisterFlagCompletionFunc("mode", cobra.FixedCompletions(wordguessModes, cobra.ShellCompDirectiveNoFileComp))
+	cmd.RegisterFlagCompletionFunc("category", cobra.FixedCompletions(wordguess.Categories(), cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
 }
 
 func init() {
-	rootCmd.AddCommand(wordguessCmd)
+	registry.Register(registry.Game{
+		Name:        "wordguess",
+		Description: "Guess a GitHub-related term one letter at a time",
+		MinPlayers:  1,
+		MaxPlayers:  1,
+		Modes:       []string{"solo"},
+		NewCommand:  newWordguessCmd,
+	})
 }
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chrisreddington/gh-game/internal/config"
+	"github.com/chrisreddington/gh-game/internal/registry"
+)
+
+// useConfig makes cfg the configuration commands load for the rest of the test
+func useConfig(t *testing.T, settings map[string]string) *config.Config {
+	t.Helper()
+	cfg, err := config.Load(filepath.Join(t.TempDir(), config.FileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, value := range settings {
+		if err := cfg.Set(key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	userConfig = cfg
+	t.Cleanup(func() { userConfig = nil })
+	return cfg
+}
+
+func TestWordguess_ConfiguredMaxIncorrectWithWordle(t *testing.T) {
+	cfg := useConfig(t, map[string]string{"wordguess.max-incorrect": "8"})
+	game, _ := registry.Lookup("wordguess")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"configured value is ignored", []string{"--mode", "wordle"}, ""},
+		{"flag is rejected", []string{"--mode", "wordle", "--max-incorrect", "3"}, "--max-incorrect cannot be used with --mode wordle"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newGameCommand(game)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			err := cmd.PreRunE(cmd, nil)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("PreRunE() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("PreRunE() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	cmd := newGameCommand(game)
+	if err := cmd.PreRunE(cmd, nil); err != nil {
+		t.Fatalf("PreRunE() unexpected error: %v", err)
+	}
+	if got := cmd.Flags().Lookup("max-incorrect").Value.String(); got != "8" {
+		t.Errorf("classic mode max-incorrect = %s, want the configured 8", got)
+	}
+
+	if err := validateGameConfig(cfg, game.NewCommand(), "wordguess.mode", "wordle"); err != nil {
+		t.Errorf("setting wordguess.mode to wordle unexpected error: %v", err)
+	}
+}
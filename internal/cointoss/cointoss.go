@@ -3,7 +3,11 @@ package cointoss
 import (
 	"fmt"
 	"math/rand"
+	"os"
 	"strings"
+
+	"github.com/chrisreddington/gh-game/internal/output"
+	"github.com/chrisreddington/gh-game/internal/stats"
 )
 
 // Game represents the state of a coin toss game
@@ -11,6 +15,7 @@ type Game struct {
 	PlayerGuess string
 	Result      string
 	IsOver      bool
+	rng         *rand.Rand // Source of randomness for the coin tosses
 }
 
 // prompter interface allows us to mock the prompt functionality in tests
@@ -18,15 +23,17 @@ type prompter interface {
 	Select(prompt string, defaultValue string, options []string) (int, error)
 }
 
-func NewGame() *Game {
+// NewGame creates a new coin toss game that draws its results from rng
+func NewGame(rng *rand.Rand) *Game {
 	return &Game{
 		IsOver: false,
+		rng:    rng,
 	}
 }
 
 // TossCoin is a variable so it can be replaced in tests
-var TossCoin = func() string {
-	if rand.Float32() < 0.5 {
+var TossCoin = func(rng *rand.Rand) string {
+	if rng.Float32() < 0.5 {
 		return "heads"
 	}
 	return "tails"
@@ -46,7 +53,7 @@ func GetPlayerGuess(p prompter) (string, bool) {
 
 	answer, err := p.Select("What's your next guess? Heads, Tails or Quit?", "Heads", options)
 	if err != nil {
-		fmt.Println("Error reading input:", err)
+		fmt.Fprintln(os.Stderr, "Error reading input:", err)
 		return "", false
 	}
 
@@ -61,7 +68,7 @@ func GetPlayerGuess(p prompter) (string, bool) {
 // Play executes a round of the coin toss game
 func (g *Game) Play(guess string) {
 	g.PlayerGuess = guess
-	g.Result = TossCoin()
+	g.Result = TossCoin(g.rng)
 	g.IsOver = true
 }
 
@@ -73,24 +80,39 @@ func (g *Game) GetResult() string {
 	return fmt.Sprintf("You guessed %s but the coin landed on %s. You lose!", g.PlayerGuess, g.Result)
 }
 
-// PlayGame handles the main game loop
-func PlayGame(p prompter, initialGuess string) {
-	game := NewGame()
+// PlayGame handles the main game loop and returns the outcome of the session.
+// A session is a single game: it is lost if it ends on a wrong guess and won
+// if the player quits while their streak is intact. The streak is the number
+// of correct guesses in a row.
+func PlayGame(p prompter, out output.Output, rng *rand.Rand, initialGuess string) stats.Result {
+	game := NewGame(rng)
+	result := stats.Result{Played: 1}
 	streak := 0
 	keepPlaying := true
 	guess := strings.ToLower(strings.TrimSpace(initialGuess))
 
 	for keepPlaying {
 		game.Play(guess)
-		fmt.Println(game.GetResult())
-
-		if game.PlayerGuess == game.Result {
+		won := game.PlayerGuess == game.Result
+		if won {
 			streak++
-			fmt.Printf("Streak: %d\n", streak)
+		}
+		out.Event("toss", output.Fields{"guess": game.PlayerGuess, "result": game.Result, "won": won, "streak": streak})
+		out.Println(game.GetResult())
+
+		if won {
+			out.Printf("Streak: %d\n", streak)
 			guess, keepPlaying = GetPlayerGuess(p)
 		} else {
-			fmt.Printf("Game Over! Final streak: %d\n", streak)
+			out.Printf("Game Over! Final streak: %d\n", streak)
+			result.Losses++
 			keepPlaying = false
 		}
 	}
+
+	if result.Losses == 0 {
+		result.Wins = 1
+	}
+	result.Streak = streak
+	return result
 }
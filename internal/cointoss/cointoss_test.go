@@ -2,10 +2,19 @@ package cointoss
 
 import (
 	"errors"
+	"math/rand"
 	"strings"
 	"testing"
+
+	"github.com/chrisreddington/gh-game/internal/output"
+	"github.com/chrisreddington/gh-game/internal/stats"
 )
 
+// newTestRand returns a deterministic random source for tests.
+func newTestRand() *rand.Rand {
+	return rand.New(rand.NewSource(1))
+}
+
 // mockPrompter implements the Prompter interface for cointoss game testing.
 // It provides configurable responses for testing user interactions.
 type mockPrompter struct {
@@ -37,14 +46,26 @@ func TestValidateGuess(t *testing.T) {
 }
 
 func TestTossCoin(t *testing.T) {
+	rng := newTestRand()
 	for i := 0; i < 100; i++ {
-		result := TossCoin()
+		result := TossCoin(rng)
 		if result != "heads" && result != "tails" {
 			t.Errorf("TossCoin() returned unexpected value %q", result)
 		}
 	}
 }
 
+func TestTossCoin_SameSeedSameResults(t *testing.T) {
+	first := rand.New(rand.NewSource(42))
+	second := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 20; i++ {
+		if a, b := TossCoin(first), TossCoin(second); a != b {
+			t.Fatalf("toss %d differs between sources with the same seed: %q vs %q", i, a, b)
+		}
+	}
+}
+
 func TestGetPlayerGuess(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -101,7 +122,7 @@ func TestGetPlayerGuess(t *testing.T) {
 }
 
 func TestGame_Play(t *testing.T) {
-	game := NewGame()
+	game := NewGame(newTestRand())
 
 	// Test initial state
 	if game.IsOver {
@@ -186,23 +207,27 @@ func TestPlayGame(t *testing.T) {
 		selectError  error
 		initialGuess string
 		results      []string // sequence of coin flip results to test
+		wantResult   stats.Result
 	}{
 		{
 			name:         "win first round then quit",
 			selectAnswer: 2, // quit
 			initialGuess: "heads",
 			results:      []string{"heads"},
+			wantResult:   stats.Result{Played: 1, Wins: 1, Streak: 1},
 		},
 		{
 			name:         "lose first round",
 			initialGuess: "heads",
 			results:      []string{"tails"},
+			wantResult:   stats.Result{Played: 1, Losses: 1},
 		},
 		{
 			name:         "win twice then lose",
 			selectAnswer: 0, // heads
 			initialGuess: "heads",
 			results:      []string{"heads", "heads", "tails"},
+			wantResult:   stats.Result{Played: 1, Losses: 1, Streak: 2},
 		},
 	}
 
@@ -216,7 +241,7 @@ func TestPlayGame(t *testing.T) {
 			// Override TossCoin for deterministic testing
 			resultIndex := 0
 			oldTossCoin := TossCoin
-			TossCoin = func() string {
+			TossCoin = func(rng *rand.Rand) string {
 				result := tt.results[resultIndex]
 				if resultIndex < len(tt.results)-1 {
 					resultIndex++
@@ -225,7 +250,9 @@ func TestPlayGame(t *testing.T) {
 			}
 			defer func() { TossCoin = oldTossCoin }()
 
-			PlayGame(mockP, tt.initialGuess)
+			if got := PlayGame(mockP, output.Discard, newTestRand(), tt.initialGuess); got != tt.wantResult {
+				t.Errorf("PlayGame() = %+v, want %+v", got, tt.wantResult)
+			}
 		})
 	}
 }
@@ -0,0 +1,194 @@
+// Package config keeps the user's default settings for gh-game in a YAML
+// file, so a team can set its standard options once instead of passing flags
+// every time.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/chrisreddington/gh-game/internal/configdir"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the configuration file inside the gh-game config directory.
+const FileName = "config.yaml"
+
+// Config holds default values for command-line flags. A key is either the
+// name of a flag that applies to every command, such as "theme", or a game
+// name and one of its flags joined by a dot, such as "higherlower.max".
+//
+// In the file, game settings are grouped under the game's name:
+//
+//	theme: high-contrast
+//	higherlower:
+//	  min: 1
+//	  max: 1000
+type Config struct {
+	path   string
+	values map[string]string
+}
+
+// DefaultPath returns the location of the configuration file in the gh-game config directory.
+func DefaultPath() string {
+	return filepath.Join(configdir.Dir(), FileName)
+}
+
+// Load reads the configuration stored at path.
+// A missing file is not an error and results in an empty configuration.
+func Load(path string) (*Config, error) {
+	cfg := &Config{path: path, values: map[string]string{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading configuration: %w", err)
+	}
+
+	var file map[string]any
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing configuration in %s: %w", path, err)
+	}
+	for key, value := range file {
+		section, ok := value.(map[string]any)
+		if !ok {
+			cfg.values[key] = scalar(value)
+			continue
+		}
+		for name, value := range section {
+			cfg.values[key+"."+name] = scalar(value)
+		}
+	}
+	return cfg, nil
+}
+
+// scalar converts a value read from YAML back to the text a flag accepts.
+func scalar(value any) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// typed returns value as the number or boolean it holds, if any, so the
+// file reads "max: 1000" rather than "max: \"1000\"".
+func typed(value string) any {
+	var parsed any
+	if err := yaml.Unmarshal([]byte(value), &parsed); err != nil {
+		return value
+	}
+	switch parsed.(type) {
+	case int, float64, bool:
+		if scalar(parsed) == value {
+			return parsed
+		}
+	}
+	return value
+}
+
+// Get returns the value set for key.
+func (c *Config) Get(key string) (string, bool) {
+	value, ok := c.values[key]
+	return value, ok
+}
+
+// Set stores value under key, replacing any earlier value.
+func (c *Config) Set(key, value string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	// A key cannot be both a value and a group of game settings
+	for existing := range c.values {
+		if strings.HasPrefix(existing, key+".") || strings.HasPrefix(key, existing+".") {
+			return fmt.Errorf("%s conflicts with %s", key, existing)
+		}
+	}
+	c.values[key] = value
+	return nil
+}
+
+// Unset removes key. It reports whether key was set.
+func (c *Config) Unset(key string) bool {
+	_, ok := c.values[key]
+	delete(c.values, key)
+	return ok
+}
+
+// Keys returns every key that has a value, in alphabetical order.
+func (c *Config) Keys() []string {
+	keys := make([]string, 0, len(c.values))
+	for key := range c.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ValidateKey checks that key is a flag name, optionally prefixed by a game name.
+func ValidateKey(key string) error {
+	parts := strings.Split(key, ".")
+	if len(parts) > 2 {
+		return fmt.Errorf("invalid key %q: use <flag> or <game>.<flag>", key)
+	}
+	for _, part := range parts {
+		if part == "" {
+			return fmt.Errorf("invalid key %q: use <flag> or <game>.<flag>", key)
+		}
+	}
+	return nil
+}
+
+// Save writes the configuration back to the file it was loaded from,
+// creating the directory if needed. The file is replaced atomically so an
+// interrupted write never leaves a corrupt configuration behind.
+func (c *Config) Save() error {
+	file := map[string]any{}
+	for key, value := range c.values {
+		game, name, ok := strings.Cut(key, ".")
+		if !ok {
+			file[key] = typed(value)
+			continue
+		}
+		section, _ := file[game].(map[string]any)
+		if section == nil {
+			section = map[string]any{}
+			file[game] = section
+		}
+		section[name] = typed(value)
+	}
+
+	data, err := yaml.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("encoding configuration: %w", err)
+	}
+
+	dir := filepath.Dir(c.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating configuration directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, FileName+".*")
+	if err != nil {
+		return fmt.Errorf("writing configuration: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing configuration: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing configuration: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("writing configuration: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoad_MissingFile(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), FileName))
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if len(cfg.Keys()) != 0 {
+		t.Errorf("Keys() = %v, want none", cfg.Keys())
+	}
+}
+
+func TestLoad_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("higherlower: [unclosed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load() expected an error for an invalid file")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	data := `theme: high-contrast
+higherlower:
+  min: 1
+  max: 1000
+rockpaperscissors:
+  spock: true
+`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		"theme":                   "high-contrast",
+		"higherlower.min":         "1",
+		"higherlower.max":         "1000",
+		"rockpaperscissors.spock": "true",
+	}
+	for key, value := range want {
+		if got, ok := cfg.Get(key); !ok || got != value {
+			t.Errorf("Get(%q) = %q, %v, want %q, true", key, got, ok, value)
+		}
+	}
+	if _, ok := cfg.Get("higherlower"); ok {
+		t.Error("Get(\"higherlower\") found a value for a group of settings")
+	}
+}
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr string
+	}{
+		{name: "global flag", key: "theme"},
+		{name: "game flag", key: "wordguess.max-incorrect"},
+		{name: "too many parts", key: "a.b.c", wantErr: "invalid key"},
+		{name: "empty game", key: ".max", wantErr: "invalid key"},
+		{name: "empty flag", key: "higherlower.", wantErr: "invalid key"},
+		{name: "value over a group", key: "higherlower", wantErr: "conflicts with higherlower.max"},
+		{name: "group over a value", key: "seed.value", wantErr: "conflicts with seed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, _ := Load(filepath.Join(t.TempDir(), FileName))
+			cfg.values["higherlower.max"] = "1000"
+			cfg.values["seed"] = "42"
+
+			err := cfg.Set(tt.key, "1")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Set() unexpected error: %v", err)
+				}
+				if got, _ := cfg.Get(tt.key); got != "1" {
+					t.Errorf("Get() after Set() = %q, want %q", got, "1")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Set() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnset(t *testing.T) {
+	cfg, _ := Load(filepath.Join(t.TempDir(), FileName))
+	cfg.Set("higherlower.max", "1000")
+
+	if !cfg.Unset("higherlower.max") {
+		t.Error("Unset() = false for a key that was set")
+	}
+	if cfg.Unset("higherlower.max") {
+		t.Error("Unset() = true for a key that was not set")
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", FileName)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.Set("theme", "monochrome")
+	cfg.Set("higherlower.max", "1000")
+	cfg.Set("higherlower.min", "10")
+	cfg.Set("rockpaperscissors.spock", "true")
+	cfg.Set("tictactoe.mode", "computer")
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantFile := `higherlower:
+    max: 1000
+    min: 10
+rockpaperscissors:
+    spock: true
+theme: monochrome
+tictactoe:
+    mode: computer
+`
+	if string(data) != wantFile {
+		t.Errorf("saved file =\n%s\nwant\n%s", data, wantFile)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.values, cfg.values) {
+		t.Errorf("loaded values = %v, want %v", loaded.values, cfg.values)
+	}
+}
@@ -0,0 +1,18 @@
+// Package configdir locates the directory where gh-game keeps its local files.
+package configdir
+
+import (
+	"path/filepath"
+
+	"github.com/cli/go-gh/v2/pkg/config"
+)
+
+// Name is the directory gh-game uses inside the GitHub CLI config directory.
+const Name = "gh-game"
+
+// Dir returns the gh-game directory inside the GitHub CLI config directory.
+// It follows the same precedence as gh itself: GH_CONFIG_DIR, then
+// XDG_CONFIG_HOME, then the platform default.
+func Dir() string {
+	return filepath.Join(config.ConfigDir(), Name)
+}
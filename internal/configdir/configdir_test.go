@@ -0,0 +1,37 @@
+package configdir
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDir(t *testing.T) {
+	tests := []struct {
+		name          string
+		ghConfigDir   string
+		xdgConfigHome string
+		want          string
+	}{
+		{
+			name:        "GH_CONFIG_DIR takes precedence",
+			ghConfigDir: filepath.Join("custom", "gh"),
+			want:        filepath.Join("custom", "gh", Name),
+		},
+		{
+			name:          "XDG_CONFIG_HOME is respected",
+			xdgConfigHome: filepath.Join("xdg"),
+			want:          filepath.Join("xdg", "gh", Name),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GH_CONFIG_DIR", tt.ghConfigDir)
+			t.Setenv("XDG_CONFIG_HOME", tt.xdgConfigHome)
+
+			if got := Dir(); got != tt.want {
+				t.Errorf("Dir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
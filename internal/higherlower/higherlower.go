@@ -4,20 +4,22 @@ package higherlower
 import (
 	"fmt"
 	"math/rand"
+	"os"
 	"strings"
-	"time"
 
-	"github.com/charmbracelet/lipgloss"
+	"github.com/chrisreddington/gh-game/internal/output"
+	"github.com/chrisreddington/gh-game/internal/stats"
+	"github.com/chrisreddington/gh-game/internal/theme"
 )
 
 // Define styles for the game
 var (
-	titleStyle     = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99"))  // purple
-	numberStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))  // blue
-	correctStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))             // green
-	incorrectStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))              // red
-	guessStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("226"))            // yellow
-	streakStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("208")) // orange
+	titleStyle     = theme.Title
+	numberStyle    = theme.Highlight
+	correctStyle   = theme.Success
+	incorrectStyle = theme.Failure
+	guessStyle     = theme.Accent
+	streakStyle    = theme.Score
 )
 
 // Game represents the state of a Higher or Lower game
@@ -29,6 +31,7 @@ type Game struct {
 	IsOver        bool
 	MinNumber     int
 	MaxNumber     int
+	rng           *rand.Rand // Source of randomness for the numbers
 }
 
 // prompter interface allows us to mock the prompt functionality in tests
@@ -36,13 +39,10 @@ type prompter interface {
 	Select(prompt string, defaultValue string, options []string) (int, error)
 }
 
-// NewGame creates a new Higher or Lower game with default settings
-func NewGame(minNumber, maxNumber int) *Game {
-	// Generate a local random generator with its own source
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-
+// NewGame creates a new Higher or Lower game that draws its numbers from rng
+func NewGame(minNumber, maxNumber int, rng *rand.Rand) *Game {
 	// Generate initial number
-	currentNumber := rng.Intn(maxNumber-minNumber+1) + minNumber
+	currentNumber := DefaultGenerateNumber(rng, minNumber, maxNumber)
 
 	return &Game{
 		CurrentNumber: currentNumber,
@@ -50,6 +50,7 @@ func NewGame(minNumber, maxNumber int) *Game {
 		IsOver:        false,
 		MinNumber:     minNumber,
 		MaxNumber:     maxNumber,
+		rng:           rng,
 	}
 }
 
@@ -69,7 +70,7 @@ func GetPlayerGuess(p prompter, currentNumber int) (string, bool) {
 
 	answer, err := p.Select(prompt, "Higher", options)
 	if err != nil {
-		fmt.Println("Error reading input:", err)
+		fmt.Fprintln(os.Stderr, "Error reading input:", err)
 		return "", false
 	}
 
@@ -82,18 +83,16 @@ func GetPlayerGuess(p prompter, currentNumber int) (string, bool) {
 }
 
 // GenerateNumberFunc is a function type for generating random numbers
-type GenerateNumberFunc func(min, max int) int
+type GenerateNumberFunc func(rng *rand.Rand, min, max int) int
 
 // DefaultGenerateNumber generates a random number between min and max (inclusive)
-var DefaultGenerateNumber = func(min, max int) int {
-	// Create a new random source each time for better randomness
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+var DefaultGenerateNumber GenerateNumberFunc = func(rng *rand.Rand, min, max int) int {
 	return rng.Intn(max-min+1) + min
 }
 
 // GenerateNextNumber produces the next random number for the game
 func (g *Game) GenerateNextNumber() {
-	g.NextNumber = DefaultGenerateNumber(g.MinNumber, g.MaxNumber)
+	g.NextNumber = DefaultGenerateNumber(g.rng, g.MinNumber, g.MaxNumber)
 }
 
 // Play executes a round of the Higher or Lower game
@@ -121,7 +120,7 @@ func (g *Game) Play(guess string) {
 
 // GetResult returns the game result message
 func (g *Game) GetResult() string {
-	var outcomeStyle lipgloss.Style
+	var outcomeStyle theme.Role
 	outcome := "Correct"
 	if !g.IsCorrect {
 		outcome = "Incorrect"
@@ -156,14 +155,18 @@ func (g *Game) UpdateForNextRound() {
 	g.CurrentNumber = g.NextNumber
 }
 
-// PlayGame handles the main game loop
-func PlayGame(p prompter, minNumber, maxNumber int) {
+// PlayGame handles the main game loop and returns the outcome of the session.
+// A session is a single game: it is lost if it ends on a wrong guess and won
+// if the player quits while their streak is intact. Quitting before the first
+// guess is not counted as played. The streak is the number of correct guesses
+// in a row.
+func PlayGame(p prompter, out output.Output, rng *rand.Rand, minNumber, maxNumber int) stats.Result {
 	title := titleStyle.Render("Welcome to Higher or Lower!")
 	rangeText := fmt.Sprintf("Numbers range from %s to %s",
 		numberStyle.Render(fmt.Sprintf("%d", minNumber)),
 		numberStyle.Render(fmt.Sprintf("%d", maxNumber)))
 
-	fmt.Printf("%s %s\n\n", title, rangeText)
+	out.Printf("%s %s\n\n", title, rangeText)
 
 	// Display game rules
 	rules := []string{
@@ -176,34 +179,54 @@ func PlayGame(p prompter, minNumber, maxNumber int) {
 	}
 
 	for _, rule := range rules {
-		fmt.Println(rule)
+		out.Println(rule)
 	}
-	fmt.Println()
+	out.Println()
 
-	game := NewGame(minNumber, maxNumber)
+	game := NewGame(minNumber, maxNumber, rng)
+	var result stats.Result
 	streak := 0
 
+	out.Event("start_number", output.Fields{"number": game.CurrentNumber})
 	startingNumber := numberStyle.Render(fmt.Sprintf("%d", game.CurrentNumber))
-	fmt.Printf("Starting number: %s\n", startingNumber)
+	out.Printf("Starting number: %s\n", startingNumber)
 
 	// Get initial guess from user
 	guess, keepPlaying := GetPlayerGuess(p, game.CurrentNumber)
 
 	for keepPlaying {
+		result.Played = 1
+		previousNumber := game.CurrentNumber
 		game.Play(guess)
-		fmt.Println(game.GetResult())
-
 		if game.IsCorrect {
 			streak++
+		}
+		out.Event("next_number", output.Fields{
+			"current": previousNumber,
+			"next":    game.NextNumber,
+			"guess":   game.PlayerGuess,
+			"correct": game.IsCorrect,
+			"streak":  streak,
+		})
+		out.Println(game.GetResult())
+
+		if game.IsCorrect {
 			streakText := streakStyle.Render(fmt.Sprintf("Streak: %d", streak))
-			fmt.Printf("%s\n", streakText)
+			out.Printf("%s\n", streakText)
 			game.UpdateForNextRound()
 			guess, keepPlaying = GetPlayerGuess(p, game.CurrentNumber)
 		} else {
 			gameOver := incorrectStyle.Render("Game Over!")
 			finalStreak := streakStyle.Render(fmt.Sprintf("Final streak: %d", streak))
-			fmt.Printf("%s %s\n", gameOver, finalStreak)
+			out.Printf("%s %s\n", gameOver, finalStreak)
+			result.Losses++
 			keepPlaying = false
 		}
 	}
+
+	if result.Played == 1 && result.Losses == 0 {
+		result.Wins = 1
+	}
+	result.Streak = streak
+	return result
 }
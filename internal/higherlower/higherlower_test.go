@@ -2,10 +2,19 @@ package higherlower
 
 import (
 	"errors"
+	"math/rand"
 	"strings"
 	"testing"
+
+	"github.com/chrisreddington/gh-game/internal/output"
+	"github.com/chrisreddington/gh-game/internal/stats"
 )
 
+// newTestRand returns a deterministic random source for tests.
+func newTestRand() *rand.Rand {
+	return rand.New(rand.NewSource(1))
+}
+
 // mockPrompter implements the Prompter interface for higher/lower game testing.
 // It can be configured with either a single response or a sequence of responses.
 type mockPrompter struct {
@@ -164,7 +173,7 @@ func TestGamePlay(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Set up deterministic number generation
 			nextNumber := tt.nextNumber
-			DefaultGenerateNumber = func(min, max int) int {
+			DefaultGenerateNumber = func(rng *rand.Rand, min, max int) int {
 				return nextNumber
 			}
 
@@ -192,8 +201,9 @@ func TestNewGame(t *testing.T) {
 	maxNumber := 100
 
 	// Test multiple games to ensure current number is within range
+	rng := newTestRand()
 	for i := 0; i < 100; i++ {
-		game := NewGame(minNumber, maxNumber)
+		game := NewGame(minNumber, maxNumber, rng)
 
 		if game.CurrentNumber < minNumber || game.CurrentNumber > maxNumber {
 			t.Errorf("NewGame() CurrentNumber = %d, want between %d and %d",
@@ -206,6 +216,22 @@ func TestNewGame(t *testing.T) {
 	}
 }
 
+func TestNewGame_SameSeedSameNumbers(t *testing.T) {
+	first := NewGame(1, 100, rand.New(rand.NewSource(42)))
+	second := NewGame(1, 100, rand.New(rand.NewSource(42)))
+
+	for i := 0; i < 20; i++ {
+		if first.CurrentNumber != second.CurrentNumber {
+			t.Fatalf("round %d: CurrentNumber differs between games with the same seed: %d vs %d",
+				i, first.CurrentNumber, second.CurrentNumber)
+		}
+		first.GenerateNextNumber()
+		second.GenerateNextNumber()
+		first.UpdateForNextRound()
+		second.UpdateForNextRound()
+	}
+}
+
 func TestUpdateForNextRound(t *testing.T) {
 	game := &Game{
 		CurrentNumber: 50,
@@ -304,7 +330,7 @@ func TestGenerateNextNumber(t *testing.T) {
 
 	// Set up deterministic number generator
 	expectedNumber := 75
-	DefaultGenerateNumber = func(min, max int) int {
+	DefaultGenerateNumber = func(rng *rand.Rand, min, max int) int {
 		return expectedNumber
 	}
 
@@ -333,30 +359,35 @@ func TestPlayGame(t *testing.T) {
 		selectAnswers []int // Sequence of select answers (0=Higher, 1=Lower, 2=Quit)
 		expectedCalls int   // Expected number of times select is called
 		numbers       []int // Sequence of generated numbers for testing
+		wantResult    stats.Result
 	}{
 		{
 			name:          "Win one round then quit",
 			selectAnswers: []int{0, 2}, // Guess Higher, then Quit
 			expectedCalls: 2,
 			numbers:       []int{50, 75}, // Start with 50, next is 75 (correct higher guess)
+			wantResult:    stats.Result{Played: 1, Wins: 1, Streak: 1},
 		},
 		{
 			name:          "Win two rounds then quit",
 			selectAnswers: []int{0, 1, 2}, // Higher, Lower, Quit
 			expectedCalls: 3,
 			numbers:       []int{50, 75, 25}, // Start with 50, next 75, then 25
+			wantResult:    stats.Result{Played: 1, Wins: 1, Streak: 2},
 		},
 		{
 			name:          "Lose immediately",
 			selectAnswers: []int{0}, // Guess Higher and lose
 			expectedCalls: 1,
 			numbers:       []int{50, 25}, // Start with 50, next is 25 (incorrect higher guess)
+			wantResult:    stats.Result{Played: 1, Losses: 1},
 		},
 		{
 			name:          "Quit immediately",
 			selectAnswers: []int{2}, // Quit
 			expectedCalls: 1,
 			numbers:       []int{50},
+			wantResult:    stats.Result{},
 		},
 	}
 
@@ -369,7 +400,7 @@ func TestPlayGame(t *testing.T) {
 
 			// Setup deterministic number generation
 			numIndex := 0
-			DefaultGenerateNumber = func(min, max int) int {
+			DefaultGenerateNumber = func(rng *rand.Rand, min, max int) int {
 				if numIndex < len(tt.numbers) {
 					result := tt.numbers[numIndex]
 					numIndex++
@@ -380,7 +411,12 @@ func TestPlayGame(t *testing.T) {
 
 			// This test validates that PlayGame executes with the configured mock prompter
 			// The deterministic number generation allows us to control the game flow
-			PlayGame(mp, 1, 100)
+			if got := PlayGame(mp, output.Discard, newTestRand(), 1, 100); got != tt.wantResult {
+				t.Errorf("PlayGame() = %+v, want %+v", got, tt.wantResult)
+			}
+			if mp.selectIndex != tt.expectedCalls {
+				t.Errorf("PlayGame() prompted %d times, want %d", mp.selectIndex, tt.expectedCalls)
+			}
 		})
 	}
 }
@@ -0,0 +1,321 @@
+// Package launcher provides the full-screen menu shown when gh game is run
+// without a subcommand. It lists the installed games, lets the player adjust
+// each game's options and reports which game to start with which arguments.
+package launcher
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chrisreddington/gh-game/internal/theme"
+)
+
+// Kind describes how the value of an option is edited.
+type Kind int
+
+const (
+	// Text options are typed in, such as a number.
+	Text Kind = iota
+	// Toggle options are switched on and off.
+	Toggle
+	// Choice options cycle through a fixed list of values.
+	Choice
+)
+
+// Option is a setting the player can change before starting a game.
+type Option struct {
+	Name       string   // Flag name, or the name of a positional argument
+	Usage      string   // Short help shown under the option
+	Kind       Kind     // How the value is edited
+	Choices    []string // Values a Choice option cycles through
+	Default    string   // Value the game uses when the option is not given
+	Value      string   // Current value
+	Positional bool     // Passed as an argument rather than a --flag
+}
+
+// Args returns the command-line arguments that apply the option. A flag left
+// at its default adds nothing.
+func (o Option) Args() []string {
+	if o.Positional {
+		if o.Value == "" {
+			return nil
+		}
+		return []string{o.Value}
+	}
+	if o.Value == o.Default {
+		return nil
+	}
+	if o.Kind == Toggle && o.Value == "true" {
+		return []string{"--" + o.Name}
+	}
+	return []string{"--" + o.Name + "=" + o.Value}
+}
+
+// Game is an entry in the menu.
+type Game struct {
+	Name        string
+	Description string
+	Options     []Option
+}
+
+// Args returns the arguments that start the game with its current options.
+// Flags come before positional arguments.
+func (g Game) Args() []string {
+	var flags, positional []string
+	for _, option := range g.Options {
+		if option.Positional {
+			positional = append(positional, option.Args()...)
+		} else {
+			flags = append(flags, option.Args()...)
+		}
+	}
+	return append(flags, positional...)
+}
+
+// Selection is the game the player chose to start.
+type Selection struct {
+	Game string
+	Args []string
+}
+
+// screen is the part of the launcher currently shown.
+type screen int
+
+const (
+	menuScreen screen = iota
+	optionsScreen
+)
+
+var (
+	selectedStyle    = theme.Selected
+	descriptionStyle = theme.Muted
+	valueStyle       = theme.Highlight
+)
+
+// titleStyle returns the style for screen titles, which are followed by a blank line
+func titleStyle() lipgloss.Style {
+	return theme.Title.Style().MarginBottom(1)
+}
+
+// helpStyle returns the style for the key help, which is set apart by a blank line
+func helpStyle() lipgloss.Style {
+	return theme.Muted.Style().MarginTop(1)
+}
+
+// Model is the launcher's Bubble Tea model. It keeps the cursor and every
+// option the player changed, so the menu comes back as it was left after a
+// game ends.
+type Model struct {
+	games     []Game
+	screen    screen
+	cursor    int // Selected game
+	option    int // Selected option on the options screen
+	selection *Selection
+}
+
+// New creates a launcher listing games.
+func New(games []Game) *Model {
+	for i := range games {
+		for j := range games[i].Options {
+			option := &games[i].Options[j]
+			if option.Value == "" {
+				option.Value = option.Default
+			}
+		}
+	}
+	return &Model{games: games}
+}
+
+// Run shows the launcher until the player starts a game or quits. It returns
+// false if the player quit.
+func (m *Model) Run() (Selection, bool, error) {
+	m.selection = nil
+	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
+		return Selection{}, false, err
+	}
+	if m.selection == nil {
+		return Selection{}, false, nil
+	}
+	return *m.selection, true, nil
+}
+
+// Init implements tea.Model.
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	if key.Type == tea.KeyCtrlC {
+		return m, tea.Quit
+	}
+
+	if m.screen == optionsScreen {
+		return m, m.updateOptions(key)
+	}
+	return m, m.updateMenu(key)
+}
+
+// updateMenu handles a key press on the list of games.
+func (m *Model) updateMenu(key tea.KeyMsg) tea.Cmd {
+	switch key.String() {
+	case "up", "k":
+		m.cursor = (m.cursor - 1 + len(m.games)) % len(m.games)
+	case "down", "j":
+		m.cursor = (m.cursor + 1) % len(m.games)
+	case "enter":
+		if len(m.games[m.cursor].Options) == 0 {
+			return m.start()
+		}
+		m.screen = optionsScreen
+		m.option = 0
+	case "q", "esc":
+		return tea.Quit
+	}
+	return nil
+}
+
+// updateOptions handles a key press on a game's options.
+func (m *Model) updateOptions(key tea.KeyMsg) tea.Cmd {
+	game := &m.games[m.cursor]
+	option := &game.Options[m.option]
+
+	switch key.Type {
+	case tea.KeyEnter:
+		return m.start()
+	case tea.KeyEsc:
+		m.screen = menuScreen
+	case tea.KeyUp, tea.KeyShiftTab:
+		m.option = (m.option - 1 + len(game.Options)) % len(game.Options)
+	case tea.KeyDown, tea.KeyTab:
+		m.option = (m.option + 1) % len(game.Options)
+	case tea.KeyLeft:
+		option.step(-1)
+	case tea.KeyRight:
+		option.step(1)
+	case tea.KeySpace:
+		if option.Kind == Text {
+			break
+		}
+		option.step(1)
+	case tea.KeyBackspace:
+		if option.Kind == Text && option.Value != "" {
+			runes := []rune(option.Value)
+			option.Value = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes:
+		if option.Kind == Text {
+			option.Value += string(key.Runes)
+		}
+	}
+	return nil
+}
+
+// step moves a toggle or choice option to its next or previous value.
+func (o *Option) step(delta int) {
+	switch o.Kind {
+	case Toggle:
+		if o.Value == "true" {
+			o.Value = "false"
+		} else {
+			o.Value = "true"
+		}
+	case Choice:
+		if len(o.Choices) == 0 {
+			return
+		}
+		current := 0
+		for i, choice := range o.Choices {
+			if choice == o.Value {
+				current = i
+			}
+		}
+		o.Value = o.Choices[(current+delta+len(o.Choices))%len(o.Choices)]
+	}
+}
+
+// start selects the current game and closes the launcher.
+func (m *Model) start() tea.Cmd {
+	game := m.games[m.cursor]
+	m.selection = &Selection{Game: game.Name, Args: game.Args()}
+	m.screen = menuScreen
+	return tea.Quit
+}
+
+// View implements tea.Model.
+func (m *Model) View() string {
+	if m.screen == optionsScreen {
+		return m.viewOptions()
+	}
+	return m.viewMenu()
+}
+
+// viewMenu renders the list of games.
+func (m *Model) viewMenu() string {
+	var sb strings.Builder
+	sb.WriteString(titleStyle().Render("🎮 gh game"))
+	sb.WriteString("\n")
+
+	for i, game := range m.games {
+		if i == m.cursor {
+			sb.WriteString(selectedStyle.Render("▸ " + game.Name))
+		} else {
+			sb.WriteString("  " + game.Name)
+		}
+		sb.WriteString("\n")
+		sb.WriteString(descriptionStyle.Render("    " + game.Description))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(helpStyle().Render("↑/↓ select • enter choose • q quit"))
+	return sb.String()
+}
+
+// viewOptions renders the options of the selected game.
+func (m *Model) viewOptions() string {
+	game := m.games[m.cursor]
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle().Render("🎮 " + game.Name))
+	sb.WriteString("\n")
+
+	for i, option := range game.Options {
+		line := fmt.Sprintf("%s: %s", option.Name, valueStyle.Render(option.display()))
+		if i == m.option {
+			sb.WriteString(selectedStyle.Render("▸ ") + line)
+		} else {
+			sb.WriteString("  " + line)
+		}
+		sb.WriteString("\n")
+		if option.Usage != "" {
+			sb.WriteString(descriptionStyle.Render("    " + option.Usage))
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString(helpStyle().Render("↑/↓ select • ←/→ change • type to edit • enter play • esc back"))
+	return sb.String()
+}
+
+// display is how the option's value is shown.
+func (o Option) display() string {
+	switch o.Kind {
+	case Toggle:
+		if o.Value == "true" {
+			return "on"
+		}
+		return "off"
+	case Choice:
+		if o.Value == "" {
+			return "not set"
+		}
+		return "‹ " + o.Value + " ›"
+	}
+	return o.Value + "▏"
+}
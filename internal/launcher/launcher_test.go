@@ -0,0 +1,178 @@
+package launcher
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func testGames() []Game {
+	return []Game{
+		{
+			Name:        "cointoss",
+			Description: "Guess heads or tails",
+			Options: []Option{
+				{Name: "guess", Kind: Choice, Choices: []string{"heads", "tails"}, Default: "heads", Positional: true},
+			},
+		},
+		{
+			Name:        "higherlower",
+			Description: "Guess higher or lower",
+			Options: []Option{
+				{Name: "min", Kind: Text, Default: "1"},
+				{Name: "max", Kind: Text, Default: "100"},
+			},
+		},
+		{
+			Name:        "tictactoe",
+			Description: "Play Tic-tac-toe",
+			Options: []Option{
+				{Name: "mode", Kind: Choice, Choices: []string{"", "local", "computer"}},
+				{Name: "hints", Kind: Toggle, Default: "false"},
+			},
+		},
+		{
+			Name:        "wordguess",
+			Description: "Guess the word",
+		},
+	}
+}
+
+// press sends key presses to the model and returns the last command.
+func press(m *Model, keys ...tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	for _, key := range keys {
+		_, cmd = m.Update(key)
+	}
+	return cmd
+}
+
+var (
+	up        = tea.KeyMsg{Type: tea.KeyUp}
+	down      = tea.KeyMsg{Type: tea.KeyDown}
+	left      = tea.KeyMsg{Type: tea.KeyLeft}
+	right     = tea.KeyMsg{Type: tea.KeyRight}
+	space     = tea.KeyMsg{Type: tea.KeySpace}
+	enter     = tea.KeyMsg{Type: tea.KeyEnter}
+	esc       = tea.KeyMsg{Type: tea.KeyEsc}
+	backspace = tea.KeyMsg{Type: tea.KeyBackspace}
+)
+
+func runes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestSelection(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []tea.KeyMsg
+		wantGame string
+		wantArgs []string
+	}{
+		{
+			name:     "game without options starts straight away",
+			keys:     []tea.KeyMsg{up, enter},
+			wantGame: "wordguess",
+		},
+		{
+			name:     "positional choice",
+			keys:     []tea.KeyMsg{enter, right, enter},
+			wantGame: "cointoss",
+			wantArgs: []string{"tails"},
+		},
+		{
+			name:     "edited text flag",
+			keys:     []tea.KeyMsg{down, enter, down, backspace, backspace, runes("5"), runes("0"), enter},
+			wantGame: "higherlower",
+			wantArgs: []string{"--max=150"},
+		},
+		{
+			name:     "choice and toggle flags",
+			keys:     []tea.KeyMsg{down, down, enter, left, down, space, enter},
+			wantGame: "tictactoe",
+			wantArgs: []string{"--mode=computer", "--hints"},
+		},
+		{
+			name:     "options left at their defaults",
+			keys:     []tea.KeyMsg{down, down, enter, enter},
+			wantGame: "tictactoe",
+		},
+		{
+			name:     "escape returns to the menu",
+			keys:     []tea.KeyMsg{down, enter, esc, down, down, enter},
+			wantGame: "wordguess",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(testGames())
+			cmd := press(m, tt.keys...)
+
+			if cmd == nil {
+				t.Fatal("launcher did not quit after a game was chosen")
+			}
+			if m.selection == nil {
+				t.Fatal("no game selected")
+			}
+			if m.selection.Game != tt.wantGame {
+				t.Errorf("selected game = %q, want %q", m.selection.Game, tt.wantGame)
+			}
+			if !reflect.DeepEqual(m.selection.Args, tt.wantArgs) {
+				t.Errorf("args = %q, want %q", m.selection.Args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestQuit(t *testing.T) {
+	for _, key := range []tea.KeyMsg{runes("q"), esc, {Type: tea.KeyCtrlC}} {
+		m := New(testGames())
+		if cmd := press(m, key); cmd == nil {
+			t.Errorf("%q did not quit the launcher", key.String())
+		}
+		if m.selection != nil {
+			t.Errorf("%q selected %q", key.String(), m.selection.Game)
+		}
+	}
+}
+
+func TestTypingQDoesNotQuitOptions(t *testing.T) {
+	m := New(testGames())
+	if cmd := press(m, down, enter, runes("q")); cmd != nil {
+		t.Error("typing q into an option quit the launcher")
+	}
+}
+
+func TestOptionsKeptBetweenRuns(t *testing.T) {
+	m := New(testGames())
+	press(m, enter, right, enter)
+
+	m.selection = nil
+	press(m, enter, enter)
+
+	if m.selection == nil || !reflect.DeepEqual(m.selection.Args, []string{"tails"}) {
+		t.Errorf("second run selection = %+v, want cointoss tails", m.selection)
+	}
+}
+
+func TestView(t *testing.T) {
+	m := New(testGames())
+
+	menu := m.View()
+	for _, want := range []string{"cointoss", "Guess heads or tails", "wordguess"} {
+		if !strings.Contains(menu, want) {
+			t.Errorf("menu does not show %q:\n%s", want, menu)
+		}
+	}
+
+	press(m, down, down, enter)
+	options := m.View()
+	for _, want := range []string{"tictactoe", "mode", "not set", "hints", "off"} {
+		if !strings.Contains(options, want) {
+			t.Errorf("options do not show %q:\n%s", want, options)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package leaderboard
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// FileName is the name of the file holding the leaderboard inside the gist.
+const FileName = "gh-game-leaderboard.json"
+
+// restClient is the subset of go-gh's api.RESTClient used by the leaderboard.
+type restClient interface {
+	Get(path string, resp interface{}) error
+	Post(path string, body io.Reader, resp interface{}) error
+	Patch(path string, body io.Reader, resp interface{}) error
+}
+
+// Client reads and writes leaderboards stored in GitHub gists.
+type Client struct {
+	rest restClient
+}
+
+// gistFile is a single file in the gists API.
+type gistFile struct {
+	Content   string `json:"content"`
+	Truncated bool   `json:"truncated,omitempty"`
+	RawURL    string `json:"raw_url,omitempty"`
+}
+
+// gist is the part of the gists API payload the leaderboard uses.
+type gist struct {
+	ID          string              `json:"id,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Public      bool                `json:"public"`
+	Files       map[string]gistFile `json:"files"`
+}
+
+// NewClient creates a leaderboard client using a go-gh REST client,
+// typically created with api.DefaultRESTClient.
+func NewClient(rest restClient) *Client {
+	return &Client{rest: rest}
+}
+
+// CurrentUser returns the login of the authenticated GitHub user.
+func (c *Client) CurrentUser() (string, error) {
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := c.rest.Get("user", &user); err != nil {
+		return "", fmt.Errorf("looking up the current user: %w", err)
+	}
+	return user.Login, nil
+}
+
+// Fetch downloads the leaderboard stored in the gist with the given ID.
+func (c *Client) Fetch(gistID string) (Board, error) {
+	var g gist
+	if err := c.rest.Get("gists/"+gistID, &g); err != nil {
+		return Board{}, fmt.Errorf("fetching leaderboard gist %s: %w", gistID, err)
+	}
+
+	file, ok := g.Files[FileName]
+	if !ok {
+		return Board{}, fmt.Errorf("gist %s does not contain a %s file", gistID, FileName)
+	}
+
+	var board Board
+	if file.Truncated {
+		// Large files are cut short in the gist payload, so read the raw file instead
+		if err := c.rest.Get(file.RawURL, &board); err != nil {
+			return Board{}, fmt.Errorf("fetching leaderboard file: %w", err)
+		}
+		return board, nil
+	}
+
+	if err := json.Unmarshal([]byte(file.Content), &board); err != nil {
+		return Board{}, fmt.Errorf("parsing leaderboard in gist %s: %w", gistID, err)
+	}
+	return board, nil
+}
+
+// Create stores board in a new secret gist and returns the gist ID.
+func (c *Client) Create(board Board) (string, error) {
+	body, err := gistBody(board)
+	if err != nil {
+		return "", err
+	}
+
+	var created gist
+	if err := c.rest.Post("gists", body, &created); err != nil {
+		return "", fmt.Errorf("creating leaderboard gist: %w", err)
+	}
+	return created.ID, nil
+}
+
+// Update replaces the leaderboard stored in the gist with the given ID.
+func (c *Client) Update(gistID string, board Board) error {
+	body, err := gistBody(board)
+	if err != nil {
+		return err
+	}
+
+	if err := c.rest.Patch("gists/"+gistID, body, nil); err != nil {
+		return fmt.Errorf("updating leaderboard gist %s: %w", gistID, err)
+	}
+	return nil
+}
+
+// Push merges the entries of player into the leaderboard and writes it back.
+// When gistID is empty a new gist is created. Returns the gist ID and the
+// merged leaderboard.
+func (c *Client) Push(gistID, player string, entries map[string]Entry) (string, Board, error) {
+	var board Board
+	if gistID != "" {
+		var err error
+		board, err = c.Fetch(gistID)
+		if err != nil {
+			return "", Board{}, err
+		}
+	}
+	board.Set(player, entries)
+
+	if gistID == "" {
+		id, err := c.Create(board)
+		return id, board, err
+	}
+	return gistID, board, c.Update(gistID, board)
+}
+
+// gistBody encodes board as the request body for creating or updating a gist.
+func gistBody(board Board) (io.Reader, error) {
+	content, err := json.MarshalIndent(board, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding leaderboard: %w", err)
+	}
+
+	payload, err := json.Marshal(gist{
+		Description: "gh-game leaderboard",
+		Files:       map[string]gistFile{FileName: {Content: string(content)}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding leaderboard: %w", err)
+	}
+	return bytes.NewReader(payload), nil
+}
@@ -0,0 +1,225 @@
+package leaderboard
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// fakeGistServer is an httptest stand-in for the GitHub user and gists endpoints.
+type fakeGistServer struct {
+	mu      sync.Mutex
+	login   string
+	gists   map[string]gist
+	created int
+}
+
+// ServeHTTP implements the handful of REST endpoints used by the leaderboard.
+func (f *fakeGistServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/user":
+		writeTestJSON(w, map[string]string{"login": f.login})
+	case r.Method == http.MethodPost && r.URL.Path == "/gists":
+		var g gist
+		if err := json.NewDecoder(r.Body).Decode(&g); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.created++
+		g.ID = "new-gist"
+		f.gists[g.ID] = g
+		w.WriteHeader(http.StatusCreated)
+		writeTestJSON(w, g)
+	case strings.HasPrefix(r.URL.Path, "/gists/"):
+		id := strings.TrimPrefix(r.URL.Path, "/gists/")
+		existing, ok := f.gists[id]
+		if !ok {
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodPatch {
+			var update gist
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			for name, file := range update.Files {
+				existing.Files[name] = file
+			}
+			f.gists[id] = existing
+		}
+		writeTestJSON(w, existing)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// writeTestJSON encodes v as the response body.
+func writeTestJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// rewriteTransport sends every request to the test server instead of api.github.com.
+type rewriteTransport struct {
+	target *url.URL
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t rewriteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// newTestClient starts a fake gist server and returns a leaderboard client talking to it.
+func newTestClient(t *testing.T, fake *fakeGistServer) *Client {
+	t.Helper()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("parsing test server URL: %v", err)
+	}
+
+	rest, err := api.NewRESTClient(api.ClientOptions{
+		Host:      "github.com",
+		AuthToken: "test-token",
+		Transport: rewriteTransport{target: target},
+	})
+	if err != nil {
+		t.Fatalf("creating REST client: %v", err)
+	}
+	return NewClient(rest)
+}
+
+// gistWithBoard creates a gist payload containing board.
+func gistWithBoard(t *testing.T, id string, board Board) gist {
+	t.Helper()
+	content, err := json.Marshal(board)
+	if err != nil {
+		t.Fatalf("encoding board: %v", err)
+	}
+	return gist{ID: id, Files: map[string]gistFile{FileName: {Content: string(content)}}}
+}
+
+func TestCurrentUser(t *testing.T) {
+	client := newTestClient(t, &fakeGistServer{login: "monalisa", gists: map[string]gist{}})
+
+	login, err := client.CurrentUser()
+	if err != nil {
+		t.Fatalf("CurrentUser() unexpected error: %v", err)
+	}
+	if login != "monalisa" {
+		t.Errorf("CurrentUser() = %q, want monalisa", login)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	board := Board{Players: map[string]map[string]Entry{
+		"hubot": {"cointoss": {Played: 6, Wins: 5, BestStreak: 5, UpdatedAt: older}},
+	}}
+	fake := &fakeGistServer{gists: map[string]gist{
+		"abc123":  gistWithBoard(t, "abc123", board),
+		"unknown": {ID: "unknown", Files: map[string]gistFile{"notes.md": {Content: "hello"}}},
+	}}
+	client := newTestClient(t, fake)
+
+	got, err := client.Fetch("abc123")
+	if err != nil {
+		t.Fatalf("Fetch() unexpected error: %v", err)
+	}
+	if got.Players["hubot"]["cointoss"].BestStreak != 5 {
+		t.Errorf("Fetch() = %+v, want hubot's cointoss streak of 5", got)
+	}
+
+	if _, err := client.Fetch("unknown"); err == nil || !strings.Contains(err.Error(), FileName) {
+		t.Errorf("Fetch() of gist without leaderboard error = %v, want missing file error", err)
+	}
+	if _, err := client.Fetch("missing"); err == nil {
+		t.Error("Fetch() of missing gist did not return an error")
+	}
+}
+
+func TestPush_CreatesGist(t *testing.T) {
+	fake := &fakeGistServer{gists: map[string]gist{}}
+	client := newTestClient(t, fake)
+
+	id, board, err := client.Push("", "monalisa", map[string]Entry{
+		"higherlower": {Played: 1, Wins: 1, BestStreak: 7, UpdatedAt: newer},
+	})
+	if err != nil {
+		t.Fatalf("Push() unexpected error: %v", err)
+	}
+	if id != "new-gist" || fake.created != 1 {
+		t.Errorf("Push() id = %q with %d gists created, want new-gist and 1", id, fake.created)
+	}
+	if board.Players["monalisa"]["higherlower"].BestStreak != 7 {
+		t.Errorf("Push() board = %+v, want monalisa's streak of 7", board)
+	}
+}
+
+func TestPush_MergesWithExistingPlayers(t *testing.T) {
+	existing := Board{Players: map[string]map[string]Entry{
+		"hubot":    {"cointoss": {Played: 9, Wins: 8, BestStreak: 6, UpdatedAt: older}},
+		"monalisa": {"cointoss": {Played: 12, Wins: 9, BestStreak: 9, UpdatedAt: older}},
+	}}
+	fake := &fakeGistServer{gists: map[string]gist{"team": gistWithBoard(t, "team", existing)}}
+	client := newTestClient(t, fake)
+
+	_, _, err := client.Push("team", "monalisa", map[string]Entry{
+		"cointoss": {Played: 14, Wins: 10, BestStreak: 3, UpdatedAt: newer},
+	})
+	if err != nil {
+		t.Fatalf("Push() unexpected error: %v", err)
+	}
+
+	stored, err := client.Fetch("team")
+	if err != nil {
+		t.Fatalf("Fetch() after Push() unexpected error: %v", err)
+	}
+	if _, ok := stored.Players["hubot"]; !ok {
+		t.Error("Push() dropped another player's record")
+	}
+	got := stored.Players["monalisa"]["cointoss"]
+	if got.Wins != 10 || got.BestStreak != 9 {
+		t.Errorf("Push() stored %+v, want 10 wins keeping the best streak of 9", got)
+	}
+	if fake.created != 0 {
+		t.Errorf("Push() to an existing gist created %d gists, want 0", fake.created)
+	}
+}
+
+func TestGistBody(t *testing.T) {
+	body, err := gistBody(Board{})
+	if err != nil {
+		t.Fatalf("gistBody() unexpected error: %v", err)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("reading body: %v", err)
+	}
+
+	var g gist
+	if err := json.Unmarshal(data, &g); err != nil {
+		t.Fatalf("gistBody() produced invalid JSON: %v", err)
+	}
+	if g.Public {
+		t.Error("gistBody() creates a public gist, want secret")
+	}
+	if _, ok := g.Files[FileName]; !ok {
+		t.Errorf("gistBody() files = %v, want %s", g.Files, FileName)
+	}
+}
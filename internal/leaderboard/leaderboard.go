@@ -0,0 +1,146 @@
+// Package leaderboard shares best streaks and win records between players
+// by storing them in a GitHub gist that the whole team can read and update.
+package leaderboard
+
+import (
+	"sort"
+	"time"
+
+	"github.com/chrisreddington/gh-game/internal/stats"
+)
+
+// Entry is one player's record for a single game.
+type Entry struct {
+	Played     int       `json:"played"`
+	Wins       int       `json:"wins"`
+	Losses     int       `json:"losses"`
+	Draws      int       `json:"draws"`
+	BestStreak int       `json:"bestStreak"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// Board holds the records of every player, keyed by GitHub login and then by game.
+type Board struct {
+	Players map[string]map[string]Entry `json:"players"`
+}
+
+// Ranking is a player's position on the leaderboard for a single game.
+type Ranking struct {
+	Rank   int    `json:"rank"`
+	Player string `json:"player"`
+	Entry
+}
+
+// FromStats converts local statistics into leaderboard entries stamped with at.
+func FromStats(store *stats.Store, at time.Time) map[string]Entry {
+	entries := make(map[string]Entry, len(store.Games))
+	for _, name := range store.Names() {
+		gameStats, _ := store.Get(name)
+		entries[name] = Entry{
+			Played:     gameStats.Played,
+			Wins:       gameStats.Wins,
+			Losses:     gameStats.Losses,
+			Draws:      gameStats.Draws,
+			BestStreak: gameStats.BestStreak,
+			UpdatedAt:  at,
+		}
+	}
+	return entries
+}
+
+// Set replaces the entries of player with entries, merging each one with
+// any record the board already holds for that player.
+func (b *Board) Set(player string, entries map[string]Entry) {
+	b.Merge(Board{Players: map[string]map[string]Entry{player: entries}})
+}
+
+// Merge folds the records of other into the board. When both boards hold a
+// record for the same player and game, the most recently updated one wins,
+// but a best streak is never lowered.
+func (b *Board) Merge(other Board) {
+	if b.Players == nil {
+		b.Players = map[string]map[string]Entry{}
+	}
+
+	for player, games := range other.Players {
+		current, ok := b.Players[player]
+		if !ok {
+			current = map[string]Entry{}
+			b.Players[player] = current
+		}
+
+		for game, incoming := range games {
+			existing, ok := current[game]
+			if !ok {
+				current[game] = incoming
+				continue
+			}
+
+			merged := existing
+			if incoming.UpdatedAt.After(existing.UpdatedAt) {
+				merged = incoming
+			}
+			merged.BestStreak = max(existing.BestStreak, incoming.BestStreak)
+			current[game] = merged
+		}
+	}
+}
+
+// Games returns the names of every game with at least one record, sorted alphabetically.
+func (b Board) Games() []string {
+	seen := map[string]bool{}
+	var games []string
+	for _, entries := range b.Players {
+		for game := range entries {
+			if !seen[game] {
+				seen[game] = true
+				games = append(games, game)
+			}
+		}
+	}
+	sort.Strings(games)
+	return games
+}
+
+// Rank orders the players who have played game. Games scored by streak rank
+// players by their best streak first; all other games rank by wins first.
+// Players with identical records share a rank.
+func (b Board) Rank(game string, byStreak bool) []Ranking {
+	var rankings []Ranking
+	for player, entries := range b.Players {
+		if entry, ok := entries[game]; ok && entry.Played > 0 {
+			rankings = append(rankings, Ranking{Player: player, Entry: entry})
+		}
+	}
+
+	score := func(e Entry) [3]int {
+		if byStreak {
+			return [3]int{e.BestStreak, e.Wins, -e.Losses}
+		}
+		return [3]int{e.Wins, -e.Losses, e.BestStreak}
+	}
+	better := func(a, c [3]int) bool {
+		for i := range a {
+			if a[i] != c[i] {
+				return a[i] > c[i]
+			}
+		}
+		return false
+	}
+
+	sort.Slice(rankings, func(i, j int) bool {
+		si, sj := score(rankings[i].Entry), score(rankings[j].Entry)
+		if si != sj {
+			return better(si, sj)
+		}
+		return rankings[i].Player < rankings[j].Player
+	})
+
+	for i := range rankings {
+		rankings[i].Rank = i + 1
+		if i > 0 && score(rankings[i].Entry) == score(rankings[i-1].Entry) {
+			rankings[i].Rank = rankings[i-1].Rank
+		}
+	}
+	return rankings
+}
@@ -0,0 +1,136 @@
+package leaderboard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrisreddington/gh-game/internal/stats"
+)
+
+var (
+	older = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer = older.Add(time.Hour)
+)
+
+func TestFromStats(t *testing.T) {
+	store := &stats.Store{Games: map[string]*stats.GameStats{}}
+	store.Record("cointoss", stats.Result{Played: 1, Losses: 1, Streak: 4}, older)
+
+	entries := FromStats(store, newer)
+	want := Entry{Played: 1, Losses: 1, BestStreak: 4, UpdatedAt: newer}
+	if got := entries["cointoss"]; got != want {
+		t.Errorf("FromStats()[cointoss] = %+v, want %+v", got, want)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing Entry
+		incoming Entry
+		want     Entry
+	}{
+		{
+			name:     "newer record replaces older",
+			existing: Entry{Played: 2, Wins: 1, BestStreak: 1, UpdatedAt: older},
+			incoming: Entry{Played: 5, Wins: 3, BestStreak: 2, UpdatedAt: newer},
+			want:     Entry{Played: 5, Wins: 3, BestStreak: 2, UpdatedAt: newer},
+		},
+		{
+			name:     "older record does not replace newer",
+			existing: Entry{Played: 5, Wins: 3, BestStreak: 2, UpdatedAt: newer},
+			incoming: Entry{Played: 2, Wins: 1, BestStreak: 1, UpdatedAt: older},
+			want:     Entry{Played: 5, Wins: 3, BestStreak: 2, UpdatedAt: newer},
+		},
+		{
+			name:     "best streak is never lowered",
+			existing: Entry{Played: 5, BestStreak: 9, UpdatedAt: older},
+			incoming: Entry{Played: 1, BestStreak: 2, UpdatedAt: newer},
+			want:     Entry{Played: 1, BestStreak: 9, UpdatedAt: newer},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := Board{Players: map[string]map[string]Entry{
+				"monalisa": {"cointoss": tt.existing},
+			}}
+			board.Merge(Board{Players: map[string]map[string]Entry{
+				"monalisa": {"cointoss": tt.incoming},
+			}})
+
+			if got := board.Players["monalisa"]["cointoss"]; got != tt.want {
+				t.Errorf("Merge() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMerge_KeepsOtherPlayersAndGames(t *testing.T) {
+	var board Board
+	board.Set("monalisa", map[string]Entry{"cointoss": {Played: 2, Wins: 2, UpdatedAt: older}})
+	board.Set("hubot", map[string]Entry{"tictactoe": {Played: 3, Wins: 3, UpdatedAt: older}})
+	board.Set("monalisa", map[string]Entry{"wordguess": {Played: 1, Wins: 1, UpdatedAt: newer}})
+
+	if len(board.Players) != 2 {
+		t.Fatalf("Merge() has %d players, want 2", len(board.Players))
+	}
+	if len(board.Players["monalisa"]) != 2 {
+		t.Errorf("Merge() kept %d games for monalisa, want 2", len(board.Players["monalisa"]))
+	}
+
+	games := board.Games()
+	want := []string{"cointoss", "tictactoe", "wordguess"}
+	if len(games) != len(want) {
+		t.Fatalf("Games() = %v, want %v", games, want)
+	}
+	for i := range want {
+		if games[i] != want[i] {
+			t.Errorf("Games()[%d] = %q, want %q", i, games[i], want[i])
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	board := Board{Players: map[string]map[string]Entry{
+		"monalisa": {"cointoss": {Played: 9, Wins: 6, Losses: 3, BestStreak: 4}},
+		"hubot":    {"cointoss": {Played: 21, Wins: 12, Losses: 9, BestStreak: 3}},
+		"octocat":  {"cointoss": {Played: 9, Wins: 6, Losses: 3, BestStreak: 4}},
+		"mona":     {"wordguess": {Played: 1, Wins: 1}},
+	}}
+
+	tests := []struct {
+		name        string
+		byStreak    bool
+		wantPlayers []string
+		wantRanks   []int
+	}{
+		{
+			name:        "ranked by best streak",
+			byStreak:    true,
+			wantPlayers: []string{"monalisa", "octocat", "hubot"},
+			wantRanks:   []int{1, 1, 3},
+		},
+		{
+			name:        "ranked by wins",
+			byStreak:    false,
+			wantPlayers: []string{"hubot", "monalisa", "octocat"},
+			wantRanks:   []int{1, 2, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rankings := board.Rank("cointoss", tt.byStreak)
+			if len(rankings) != len(tt.wantPlayers) {
+				t.Fatalf("Rank() returned %d players, want %d", len(rankings), len(tt.wantPlayers))
+			}
+			for i, ranking := range rankings {
+				if ranking.Player != tt.wantPlayers[i] || ranking.Rank != tt.wantRanks[i] {
+					t.Errorf("Rank()[%d] = %s at %d, want %s at %d",
+						i, ranking.Player, ranking.Rank, tt.wantPlayers[i], tt.wantRanks[i])
+				}
+			}
+		})
+	}
+}
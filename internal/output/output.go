@@ -0,0 +1,117 @@
+// Package output separates what a game wants to show from where it ends up,
+// so the same game can print styled text to a terminal, emit JSON lines for
+// scripts or be recorded.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Fields holds the data attached to an event.
+type Fields map[string]any
+
+// Output receives everything a game shows the player.
+type Output interface {
+	// Print writes text for the player, like fmt.Print.
+	Print(a ...any)
+	// Printf writes formatted text for the player, like fmt.Printf.
+	Printf(format string, a ...any)
+	// Println writes a line of text for the player, like fmt.Println.
+	Println(a ...any)
+	// Event reports something that happened in the game, such as a random
+	// draw or the outcome of a round, as structured data.
+	Event(name string, fields Fields)
+}
+
+// secretOutput is implemented by outputs that keep a record of the game for
+// later, rather than showing it to the player as it happens.
+type secretOutput interface {
+	SecretEvent(name string, fields Fields)
+}
+
+// Secret reports an event the player must not see while playing, such as the
+// word they are trying to guess. It only reaches outputs that keep a record of
+// the game, like a session log, and is dropped by every other output.
+func Secret(out Output, name string, fields Fields) {
+	if secret, ok := out.(secretOutput); ok {
+		secret.SecretEvent(name, fields)
+	}
+}
+
+// text is an Output that writes prose and ignores events.
+type text struct {
+	w io.Writer
+}
+
+// NewText creates an Output that writes the game's text to w.
+// Events are not shown, since the text already describes them.
+func NewText(w io.Writer) Output {
+	return &text{w: w}
+}
+
+// Print implements Output.
+func (t *text) Print(a ...any) {
+	fmt.Fprint(t.w, a...)
+}
+
+// Printf implements Output.
+func (t *text) Printf(format string, a ...any) {
+	fmt.Fprintf(t.w, format, a...)
+}
+
+// Println implements Output.
+func (t *text) Println(a ...any) {
+	fmt.Fprintln(t.w, a...)
+}
+
+// Event implements Output.
+func (t *text) Event(name string, fields Fields) {}
+
+// Discard is an Output that throws away everything written to it.
+var Discard Output = NewText(io.Discard)
+
+// jsonLines is an Output that writes events as JSON lines and drops text.
+type jsonLines struct {
+	w io.Writer
+}
+
+// NewJSON creates an Output that writes each event to w as a single line of
+// JSON, so a game can be used from scripts. The text meant for a person is
+// dropped. Each line holds the event name under "event" followed by its fields.
+func NewJSON(w io.Writer) Output {
+	return &jsonLines{w: w}
+}
+
+// Print implements Output.
+func (j *jsonLines) Print(a ...any) {}
+
+// Printf implements Output.
+func (j *jsonLines) Printf(format string, a ...any) {}
+
+// Println implements Output.
+func (j *jsonLines) Println(a ...any) {}
+
+// Event implements Output.
+func (j *jsonLines) Event(name string, fields Fields) {
+	line, err := encodeEvent(name, fields)
+	if err != nil {
+		line, _ = encodeEvent("error", Fields{"source": name, "error": err.Error()})
+	}
+	j.w.Write(append(line, '\n'))
+}
+
+// encodeEvent encodes an event as a JSON object whose first key is "event".
+func encodeEvent(name string, fields Fields) ([]byte, error) {
+	line, err := json.Marshal(map[string]string{"event": name})
+	if err != nil || len(fields) == 0 {
+		return line, err
+	}
+	rest, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	line = append(line[:len(line)-1], ',')
+	return append(line, rest[1:]...), nil
+}
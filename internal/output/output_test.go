@@ -0,0 +1,73 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestText(t *testing.T) {
+	var buf bytes.Buffer
+	out := NewText(&buf)
+
+	out.Print("Streak: ", 3, "\n")
+	out.Printf("Current number: %d\n", 42)
+	out.Println("Game", "Over!")
+	out.Event("toss", Fields{"result": "heads"})
+
+	want := "Streak: 3\nCurrent number: 42\nGame Over!\n"
+	if got := buf.String(); got != want {
+		t.Errorf("text output = %q, want %q", got, want)
+	}
+}
+
+func TestSecret(t *testing.T) {
+	var text, lines bytes.Buffer
+	Secret(NewText(&text), "word", Fields{"word": "copilot"})
+	Secret(NewJSON(&lines), "word", Fields{"word": "copilot"})
+
+	if text.Len() != 0 || lines.Len() != 0 {
+		t.Errorf("Secret() wrote %q and %q, want nothing shown to the player", text.String(), lines.String())
+	}
+}
+
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		event  string
+		fields Fields
+		want   string
+	}{
+		{
+			name:   "fields follow the event name",
+			event:  "toss",
+			fields: Fields{"result": "heads", "guess": "tails", "streak": 0},
+			want:   `{"event":"toss","guess":"tails","result":"heads","streak":0}` + "\n",
+		},
+		{
+			name:  "no fields",
+			event: "quit",
+			want:  `{"event":"quit"}` + "\n",
+		},
+		{
+			name:   "unencodable field becomes an error event",
+			event:  "broken",
+			fields: Fields{"value": make(chan int)},
+			want:   `{"event":"error","error":"json: unsupported type: chan int","source":"broken"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			out := NewJSON(&buf)
+
+			out.Println("You guessed tails but the coin landed on heads.")
+			out.Printf("Streak: %d\n", 0)
+			out.Event(tt.event, tt.fields)
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("json output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+// Package record writes a game session to a JSON Lines log and plays it back.
+// A log holds the text the game showed, every prompt with the answer given,
+// and the structured events the game reported, such as random draws and the
+// final result.
+package record
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chrisreddington/gh-game/internal/output"
+)
+
+// Kinds of event stored in a session log.
+const (
+	KindStart  = "start"  // KindStart opens the log and names the game and seed
+	KindOutput = "output" // KindOutput is text shown to the player
+	KindPrompt = "prompt" // KindPrompt is a question asked and the answer given
+	KindEvent  = "event"  // KindEvent is a structured event reported by the game
+)
+
+// Event is a single line in a session log.
+type Event struct {
+	Seq       int           `json:"seq"`
+	ElapsedMS int64         `json:"elapsedMs"`
+	Kind      string        `json:"kind"`
+	Game      string        `json:"game,omitempty"`
+	Seed      int64         `json:"seed,omitempty"`
+	Time      time.Time     `json:"time,omitzero"`
+	Text      string        `json:"text,omitempty"`
+	Prompt    string        `json:"prompt,omitempty"`
+	Options   []string      `json:"options,omitempty"`
+	Answer    string        `json:"answer,omitempty"`
+	Error     string        `json:"error,omitempty"`
+	Name      string        `json:"name,omitempty"`
+	Fields    output.Fields `json:"fields,omitempty"`
+}
+
+// Elapsed returns how long after the start of the session the event happened.
+func (e Event) Elapsed() time.Duration {
+	return time.Duration(e.ElapsedMS) * time.Millisecond
+}
+
+// Prompter is the set of prompts the games use.
+type Prompter interface {
+	Select(prompt string, defaultValue string, options []string) (int, error)
+	Input(prompt string, defaultValue string) (string, error)
+	Confirm(prompt string, defaultValue bool) (bool, error)
+}
+
+// Recorder writes events to a session log.
+type Recorder struct {
+	encoder *json.Encoder
+	closer  io.Closer
+	start   time.Time
+	now     func() time.Time
+	seq     int
+	err     error
+}
+
+// New creates a Recorder writing to w and logs the start of a session of game.
+func New(w io.Writer, game string, seed int64) *Recorder {
+	r := &Recorder{
+		encoder: json.NewEncoder(w),
+		now:     time.Now,
+	}
+	r.start = r.now()
+	r.write(Event{Kind: KindStart, Game: game, Seed: seed, Time: r.start})
+	return r
+}
+
+// Create creates the file at path and returns a Recorder writing to it.
+func Create(path, game string, seed int64) (*Recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("creating session log: %w", err)
+	}
+	r := New(file, game, seed)
+	r.closer = file
+	return r, nil
+}
+
+// write appends an event to the log, keeping the first error encountered.
+func (r *Recorder) write(event Event) {
+	if r.err != nil {
+		return
+	}
+	r.seq++
+	event.Seq = r.seq
+	event.ElapsedMS = r.now().Sub(r.start).Milliseconds()
+	if err := r.encoder.Encode(event); err != nil {
+		r.err = fmt.Errorf("writing session log: %w", err)
+	}
+}
+
+// Close finishes the log, returning the first error encountered while writing it.
+func (r *Recorder) Close() error {
+	if r.closer != nil {
+		if err := r.closer.Close(); err != nil && r.err == nil {
+			r.err = fmt.Errorf("closing session log: %w", err)
+		}
+	}
+	return r.err
+}
+
+// Output wraps out so that everything written to it is also recorded.
+func (r *Recorder) Output(out output.Output) output.Output {
+	return &recordingOutput{recorder: r, out: out}
+}
+
+// Prompter wraps p so that every prompt and its answer is recorded.
+func (r *Recorder) Prompter(p Prompter) Prompter {
+	return &recordingPrompter{recorder: r, prompter: p}
+}
+
+// recordingOutput records text and events before passing them on.
+type recordingOutput struct {
+	recorder *Recorder
+	out      output.Output
+}
+
+// Print implements output.Output.
+func (o *recordingOutput) Print(a ...any) {
+	o.text(fmt.Sprint(a...))
+}
+
+// Printf implements output.Output.
+func (o *recordingOutput) Printf(format string, a ...any) {
+	o.text(fmt.Sprintf(format, a...))
+}
+
+// Println implements output.Output.
+func (o *recordingOutput) Println(a ...any) {
+	o.text(fmt.Sprintln(a...))
+}
+
+// Event implements output.Output.
+func (o *recordingOutput) Event(name string, fields output.Fields) {
+	o.recorder.write(Event{Kind: KindEvent, Name: name, Fields: fields})
+	o.out.Event(name, fields)
+}
+
+// SecretEvent records an event the player must not see, passing it on only
+// to outputs that also keep a record.
+func (o *recordingOutput) SecretEvent(name string, fields output.Fields) {
+	o.recorder.write(Event{Kind: KindEvent, Name: name, Fields: fields})
+	output.Secret(o.out, name, fields)
+}
+
+// text records a piece of text and shows it unchanged.
+func (o *recordingOutput) text(s string) {
+	o.recorder.write(Event{Kind: KindOutput, Text: s})
+	o.out.Print(s)
+}
+
+// recordingPrompter records prompts and the answers given to them.
+type recordingPrompter struct {
+	recorder *Recorder
+	prompter Prompter
+}
+
+// Select implements Prompter.
+func (p *recordingPrompter) Select(prompt string, defaultValue string, options []string) (int, error) {
+	index, err := p.prompter.Select(prompt, defaultValue, options)
+	event := Event{Kind: KindPrompt, Prompt: prompt, Options: options}
+	if err == nil && index >= 0 && index < len(options) {
+		event.Answer = options[index]
+	}
+	p.record(event, err)
+	return index, err
+}
+
+// Input implements Prompter.
+func (p *recordingPrompter) Input(prompt string, defaultValue string) (string, error) {
+	answer, err := p.prompter.Input(prompt, defaultValue)
+	p.record(Event{Kind: KindPrompt, Prompt: prompt, Answer: answer}, err)
+	return answer, err
+}
+
+// Confirm implements Prompter.
+func (p *recordingPrompter) Confirm(prompt string, defaultValue bool) (bool, error) {
+	answer, err := p.prompter.Confirm(prompt, defaultValue)
+	p.record(Event{Kind: KindPrompt, Prompt: prompt, Options: []string{"Yes", "No"}, Answer: confirmLabel(answer)}, err)
+	return answer, err
+}
+
+// record writes a prompt event along with any error from the prompt.
+func (p *recordingPrompter) record(event Event, err error) {
+	if err != nil {
+		event.Answer = ""
+		event.Error = err.Error()
+	}
+	p.recorder.write(event)
+}
+
+// confirmLabel is how a confirmation answer is shown.
+func confirmLabel(answer bool) string {
+	if answer {
+		return "Yes"
+	}
+	return "No"
+}
+
+// Read parses a session log.
+func Read(r io.Reader) ([]Event, error) {
+	var events []Event
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading session log: %w", err)
+	}
+
+	if len(events) == 0 || events[0].Kind != KindStart {
+		return nil, fmt.Errorf("not a session log: missing %s event", KindStart)
+	}
+	return events, nil
+}
+
+// formatFields renders event fields as space-separated key=value pairs.
+func formatFields(fields output.Fields) string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := fields[key]
+		if s, ok := value.(string); ok {
+			value = strconv.Quote(s)
+		}
+		parts = append(parts, fmt.Sprintf("%s=%v", key, value))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,220 @@
+package record
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrisreddington/gh-game/internal/output"
+)
+
+// mockPrompter implements Prompter with fixed answers.
+type mockPrompter struct {
+	selectAnswer  int
+	inputAnswer   string
+	confirmAnswer bool
+	err           error
+}
+
+// Select implements Prompter.
+func (m *mockPrompter) Select(prompt string, defaultValue string, options []string) (int, error) {
+	return m.selectAnswer, m.err
+}
+
+// Input implements Prompter.
+func (m *mockPrompter) Input(prompt string, defaultValue string) (string, error) {
+	return m.inputAnswer, m.err
+}
+
+// Confirm implements Prompter.
+func (m *mockPrompter) Confirm(prompt string, defaultValue bool) (bool, error) {
+	return m.confirmAnswer, m.err
+}
+
+// recordSession records a short session and returns the parsed log.
+func recordSession(t *testing.T) ([]Event, string) {
+	t.Helper()
+	var log, shown bytes.Buffer
+
+	recorder := New(&log, "cointoss", 42)
+	out := recorder.Output(output.NewText(&shown))
+	prompter := recorder.Prompter(&mockPrompter{selectAnswer: 1, inputAnswer: "e", confirmAnswer: true})
+
+	out.Printf("Streak: %d\n", 1)
+	out.Event("toss", output.Fields{"guess": "heads", "result": "heads"})
+	if _, err := prompter.Select("What's your next guess?", "Heads", []string{"Heads", "Tails", "Quit"}); err != nil {
+		t.Fatalf("Select() unexpected error: %v", err)
+	}
+	if _, err := prompter.Input("Enter a letter: ", ""); err != nil {
+		t.Fatalf("Input() unexpected error: %v", err)
+	}
+	if _, err := prompter.Confirm("Play again?", true); err != nil {
+		t.Fatalf("Confirm() unexpected error: %v", err)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close() unexpected error: %v", err)
+	}
+
+	events, err := Read(&log)
+	if err != nil {
+		t.Fatalf("Read() unexpected error: %v", err)
+	}
+	return events, shown.String()
+}
+
+func TestRecorder(t *testing.T) {
+	events, shown := recordSession(t)
+
+	if shown != "Streak: 1\n" {
+		t.Errorf("recorded output showed %q, want the text passed through unchanged", shown)
+	}
+
+	want := []Event{
+		{Kind: KindStart, Game: "cointoss", Seed: 42},
+		{Kind: KindOutput, Text: "Streak: 1\n"},
+		{Kind: KindEvent, Name: "toss"},
+		{Kind: KindPrompt, Prompt: "What's your next guess?", Answer: "Tails"},
+		{Kind: KindPrompt, Prompt: "Enter a letter: ", Answer: "e"},
+		{Kind: KindPrompt, Prompt: "Play again?", Answer: "Yes"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("recorded %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, event := range events {
+		if event.Seq != i+1 {
+			t.Errorf("event %d Seq = %d, want %d", i, event.Seq, i+1)
+		}
+		if event.Kind != want[i].Kind || event.Game != want[i].Game || event.Seed != want[i].Seed ||
+			event.Text != want[i].Text || event.Name != want[i].Name ||
+			event.Prompt != want[i].Prompt || event.Answer != want[i].Answer {
+			t.Errorf("event %d = %+v, want %+v", i, event, want[i])
+		}
+	}
+	if got := events[2].Fields["result"]; got != "heads" {
+		t.Errorf("toss event result = %v, want heads", got)
+	}
+}
+
+func TestRecorder_SecretEvent(t *testing.T) {
+	var log, shown bytes.Buffer
+	recorder := New(&log, "wordguess", 1)
+	out := recorder.Output(output.NewJSON(&shown))
+
+	output.Secret(out, "word", output.Fields{"word": "copilot"})
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close() unexpected error: %v", err)
+	}
+
+	if shown.Len() != 0 {
+		t.Errorf("secret event was shown as %q, want it only in the log", shown.String())
+	}
+	events, err := Read(&log)
+	if err != nil {
+		t.Fatalf("Read() unexpected error: %v", err)
+	}
+	if last := events[len(events)-1]; last.Name != "word" || last.Fields["word"] != "copilot" {
+		t.Errorf("last recorded event = %+v, want the secret word", last)
+	}
+}
+
+func TestRecorder_PromptError(t *testing.T) {
+	var log bytes.Buffer
+	recorder := New(&log, "wordguess", 1)
+	prompter := recorder.Prompter(&mockPrompter{err: errors.New("interrupted")})
+
+	if _, err := prompter.Input("Enter a letter: ", ""); err == nil {
+		t.Fatal("Input() did not pass on the prompter error")
+	}
+
+	events, err := Read(&log)
+	if err != nil {
+		t.Fatalf("Read() unexpected error: %v", err)
+	}
+	if last := events[len(events)-1]; last.Error != "interrupted" {
+		t.Errorf("prompt event Error = %q, want interrupted", last.Error)
+	}
+}
+
+func TestRead_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty log", input: ""},
+		{name: "not JSON", input: "hello\n"},
+		{name: "missing start", input: `{"seq":1,"kind":"output","text":"hi"}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Read(strings.NewReader(tt.input)); err == nil {
+				t.Error("Read() did not return an error")
+			}
+		})
+	}
+}
+
+func TestPlayer(t *testing.T) {
+	events := []Event{
+		{Kind: KindStart, Game: "cointoss", Seed: 7},
+		{Kind: KindOutput, Text: "You guessed heads and the coin landed on heads. You win!\n", ElapsedMS: 1000},
+		{Kind: KindEvent, Name: "toss", Fields: output.Fields{"result": "heads"}, ElapsedMS: 1000},
+		{Kind: KindPrompt, Prompt: "What's your next guess?", Answer: "Quit", ElapsedMS: 11000},
+	}
+
+	tests := []struct {
+		name       string
+		player     Player
+		wantPauses []time.Duration
+		wantEvents bool
+	}{
+		{
+			name:       "original speed",
+			player:     Player{Speed: 1},
+			wantPauses: []time.Duration{time.Second, 10 * time.Second},
+		},
+		{
+			name:       "double speed with capped pauses",
+			player:     Player{Speed: 2, MaxPause: 2 * time.Second},
+			wantPauses: []time.Duration{500 * time.Millisecond, 2 * time.Second},
+		},
+		{
+			name:       "no pauses with events shown",
+			player:     Player{Speed: 0, Events: true},
+			wantEvents: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			var pauses []time.Duration
+			player := tt.player
+			player.Out = &buf
+			player.Sleep = func(d time.Duration) { pauses = append(pauses, d) }
+
+			player.Play(events)
+
+			if len(pauses) != len(tt.wantPauses) {
+				t.Fatalf("Play() paused %v, want %v", pauses, tt.wantPauses)
+			}
+			for i := range pauses {
+				if pauses[i] != tt.wantPauses[i] {
+					t.Errorf("pause %d = %v, want %v", i, pauses[i], tt.wantPauses[i])
+				}
+			}
+
+			got := buf.String()
+			for _, want := range []string{"Replaying cointoss session", "seed 7", "You win!", "What's your next guess?", "Quit"} {
+				if !strings.Contains(got, want) {
+					t.Errorf("Play() output does not contain %q\nGot: %q", want, got)
+				}
+			}
+			if strings.Contains(got, "[toss]") != tt.wantEvents {
+				t.Errorf("Play() showed events = %v, want %v", !tt.wantEvents, tt.wantEvents)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package record
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/chrisreddington/gh-game/internal/theme"
+)
+
+var (
+	promptMarkStyle = theme.Success // like the interactive prompts
+	answerStyle     = theme.Highlight
+	eventStyle      = theme.Muted
+)
+
+// Player plays a recorded session back with its original rendering.
+type Player struct {
+	Out      io.Writer           // Out receives the replayed session
+	Speed    float64             // Speed scales the original pacing; 2 plays twice as fast and 0 plays without pauses
+	MaxPause time.Duration       // MaxPause caps the wait between two events, or 0 for no cap
+	Events   bool                // Events shows structured events such as random draws alongside the text
+	Sleep    func(time.Duration) // Sleep waits between events, defaulting to time.Sleep
+}
+
+// Play replays events in order, waiting between them to match the original pacing.
+func (p *Player) Play(events []Event) {
+	sleep := p.Sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+
+	var previous time.Duration
+	for _, event := range events {
+		if wait := p.pause(event.Elapsed() - previous); wait > 0 {
+			sleep(wait)
+		}
+		previous = event.Elapsed()
+		p.render(event)
+	}
+}
+
+// pause works out how long to wait for a gap in the original session.
+func (p *Player) pause(gap time.Duration) time.Duration {
+	if p.Speed <= 0 || gap <= 0 {
+		return 0
+	}
+	wait := time.Duration(float64(gap) / p.Speed)
+	if p.MaxPause > 0 && wait > p.MaxPause {
+		wait = p.MaxPause
+	}
+	return wait
+}
+
+// render writes a single event.
+func (p *Player) render(event Event) {
+	switch event.Kind {
+	case KindStart:
+		fmt.Fprintf(p.Out, "Replaying %s session recorded %s (seed %d)\n\n",
+			event.Game, event.Time.Local().Format("2006-01-02 15:04"), event.Seed)
+	case KindOutput:
+		fmt.Fprint(p.Out, event.Text)
+	case KindPrompt:
+		answer := event.Answer
+		if event.Error != "" {
+			answer = "error: " + event.Error
+		}
+		fmt.Fprintf(p.Out, "%s %s %s\n", promptMarkStyle.Render("?"), event.Prompt, answerStyle.Render(answer))
+	case KindEvent:
+		if p.Events {
+			fmt.Fprintln(p.Out, eventStyle.Render(fmt.Sprintf("[%s] %s", event.Name, formatFields(event.Fields))))
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Package registry keeps the catalogue of games available in gh-game.
+// Every game registers its metadata together with a constructor for its
+// command, and the root command builds its subcommands from the catalogue.
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+// Game describes a single game and how to build its command.
+type Game struct {
+	Name        string   `json:"name"`        // Name is the subcommand used to start the game
+	Description string   `json:"description"` // Description is a one-line summary of the game
+	MinPlayers  int      `json:"minPlayers"`  // MinPlayers is the fewest human players the game supports
+	MaxPlayers  int      `json:"maxPlayers"`  // MaxPlayers is the most human players the game supports
+	Modes       []string `json:"modes"`       // Modes lists the ways the game can be played
+	HasAI       bool     `json:"hasAI"`       // HasAI reports whether the game has a computer opponent
+
+	// ScoredByStreak reports whether players are ranked by their best streak rather than their wins.
+	ScoredByStreak bool `json:"scoredByStreak"`
+
+	// NewCommand constructs the cobra command that plays the game.
+	NewCommand func() *cobra.Command `json:"-"`
+}
+
+// Registry holds a set of games keyed by name.
+type Registry struct {
+	mu    sync.RWMutex
+	games map[string]Game
+}
+
+// New creates an empty Registry.
+func New() *Registry {
+	return &Registry{games: make(map[string]Game)}
+}
+
+// Default is the registry used by the gh-game commands.
+var Default = New()
+
+// Register adds a game to the registry.
+// Returns an error if the game has no name or constructor, or if a game
+// with the same name is already registered.
+func (r *Registry) Register(game Game) error {
+	if game.Name == "" {
+		return fmt.Errorf("game must have a name")
+	}
+	if game.NewCommand == nil {
+		return fmt.Errorf("game %q must have a command constructor", game.Name)
+	}
+	if game.MinPlayers < 0 || game.MaxPlayers < game.MinPlayers {
+		return fmt.Errorf("game %q has an invalid player range %d-%d", game.Name, game.MinPlayers, game.MaxPlayers)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.games[game.Name]; exists {
+		return fmt.Errorf("game %q is already registered", game.Name)
+	}
+	r.games[game.Name] = game
+	return nil
+}
+
+// Lookup returns the game registered under name, if any.
+func (r *Registry) Lookup(name string) (Game, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	game, ok := r.games[name]
+	return game, ok
+}
+
+// Games returns all registered games sorted by name.
+func (r *Registry) Games() []Game {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	games := make([]Game, 0, len(r.games))
+	for _, game := range r.games {
+		games = append(games, game)
+	}
+	sort.Slice(games, func(i, j int) bool {
+		return games[i].Name < games[j].Name
+	})
+	return games
+}
+
+// Register adds a game to the Default registry.
+// It panics if the game cannot be registered, since that is a programming error.
+func Register(game Game) {
+	if err := Default.Register(game); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the game registered under name in the Default registry.
+func Lookup(name string) (Game, bool) {
+	return Default.Lookup(name)
+}
+
+// Games returns all games in the Default registry sorted by name.
+func Games() []Game {
+	return Default.Games()
+}
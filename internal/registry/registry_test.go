@@ -0,0 +1,109 @@
+package registry
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// newTestCommand is a command constructor used for registering test games.
+func newTestCommand() *cobra.Command {
+	return &cobra.Command{Use: "test"}
+}
+
+func TestRegister(t *testing.T) {
+	tests := []struct {
+		name          string
+		game          Game
+		expectedError string
+	}{
+		{
+			name: "valid game",
+			game: Game{Name: "cointoss", MinPlayers: 1, MaxPlayers: 1, NewCommand: newTestCommand},
+		},
+		{
+			name:          "missing name",
+			game:          Game{MinPlayers: 1, MaxPlayers: 1, NewCommand: newTestCommand},
+			expectedError: "must have a name",
+		},
+		{
+			name:          "missing constructor",
+			game:          Game{Name: "cointoss", MinPlayers: 1, MaxPlayers: 1},
+			expectedError: "must have a command constructor",
+		},
+		{
+			name:          "invalid player range",
+			game:          Game{Name: "cointoss", MinPlayers: 2, MaxPlayers: 1, NewCommand: newTestCommand},
+			expectedError: "invalid player range",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New()
+			err := r.Register(tt.game)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("Register() error = %v, want error containing %q", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Register() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestRegisterDuplicate(t *testing.T) {
+	r := New()
+	game := Game{Name: "tictactoe", MinPlayers: 1, MaxPlayers: 2, NewCommand: newTestCommand}
+
+	if err := r.Register(game); err != nil {
+		t.Fatalf("Register() unexpected error: %v", err)
+	}
+	if err := r.Register(game); err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Errorf("Register() duplicate error = %v, want already registered error", err)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	r := New()
+	game := Game{Name: "wordguess", Description: "Guess the word", MinPlayers: 1, MaxPlayers: 1, NewCommand: newTestCommand}
+	if err := r.Register(game); err != nil {
+		t.Fatalf("Register() unexpected error: %v", err)
+	}
+
+	got, ok := r.Lookup("wordguess")
+	if !ok {
+		t.Fatal("Lookup() did not find registered game")
+	}
+	if got.Description != game.Description {
+		t.Errorf("Lookup() Description = %q, want %q", got.Description, game.Description)
+	}
+
+	if _, ok := r.Lookup("missing"); ok {
+		t.Error("Lookup() found a game that was never registered")
+	}
+}
+
+func TestGamesSortedByName(t *testing.T) {
+	r := New()
+	for _, name := range []string{"wordguess", "cointoss", "tictactoe"} {
+		if err := r.Register(Game{Name: name, MinPlayers: 1, MaxPlayers: 1, NewCommand: newTestCommand}); err != nil {
+			t.Fatalf("Register(%q) unexpected error: %v", name, err)
+		}
+	}
+
+	games := r.Games()
+	want := []string{"cointoss", "tictactoe", "wordguess"}
+	if len(games) != len(want) {
+		t.Fatalf("Games() returned %d games, want %d", len(games), len(want))
+	}
+	for i, game := range games {
+		if game.Name != want[i] {
+			t.Errorf("Games()[%d] = %q, want %q", i, game.Name, want[i])
+		}
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"math/rand"
 	"strings"
+
+	"github.com/chrisreddington/gh-game/internal/output"
+	"github.com/chrisreddington/gh-game/internal/stats"
 )
 
 // Game options
@@ -34,6 +37,8 @@ type Game struct {
 	GamesPlayed int
 	// SecretMode indicates if the game is in secret mode
 	SecretMode bool
+	// rng is the source of randomness for the computer's choices
+	rng *rand.Rand
 }
 
 // Prompter defines an interface for getting user input
@@ -41,7 +46,8 @@ type Prompter interface {
 	Select(prompt, defaultValue string, options []string) (int, error)
 }
 
-func NewGame(bestOf int, secretMode bool) *Game {
+// NewGame creates a new best-of series where the computer picks its moves using rng.
+func NewGame(bestOf int, secretMode bool, rng *rand.Rand) *Game {
 	if bestOf%2 == 0 {
 		bestOf++ // Ensure we have an odd number for "best of"
 	}
@@ -56,6 +62,7 @@ func NewGame(bestOf int, secretMode bool) *Game {
 		GameOver:        false,
 		GameOverMessage: "",
 		SecretMode:      secretMode,
+		rng:             rng,
 	}
 }
 
@@ -86,7 +93,7 @@ func (g *Game) getComputerChoice() string {
 	}
 	// Only use the game options excluding "exit"
 	choices := options[:len(options)-1]
-	return choices[rand.Intn(len(choices))]
+	return choices[g.rng.Intn(len(choices))]
 }
 
 // getWinner returns the winner of the current round.
@@ -159,28 +166,36 @@ func (g *Game) getRoundResultMessage() string {
 	}
 }
 
-// PlayGame plays a game of Rock Paper Scissors.
-func PlayGame(prompter Prompter, secretMode bool) {
-	// Get the number of rounds from the user
-	roundOptions := []string{"3", "5", "7", "9"}
-	roundIndex, err := prompter.Select("How many rounds would you like to play (best of)?", "3", roundOptions)
-	if err != nil {
-		fmt.Printf("Error getting number of rounds: %v\n", err)
-		return
-	}
-	bestOf := 3 // Default value
-	if roundIndex >= 0 && roundIndex < len(roundOptions) {
-		bestOf = parseInt(roundOptions[roundIndex])
+// PlayGame plays a game of Rock Paper Scissors and returns the outcome of the series.
+// The series is best of bestOf rounds, and the player is asked how many when
+// bestOf is zero. A series abandoned by the player is not counted as played.
+// The streak is the longest run of rounds won in a row.
+func PlayGame(prompter Prompter, out output.Output, rng *rand.Rand, bestOf int, secretMode bool) stats.Result {
+	var result stats.Result
+
+	if bestOf <= 0 {
+		// Get the number of rounds from the user
+		roundOptions := []string{"3", "5", "7", "9"}
+		roundIndex, err := prompter.Select("How many rounds would you like to play (best of)?", "3", roundOptions)
+		if err != nil {
+			out.Printf("Error getting number of rounds: %v\n", err)
+			return result
+		}
+		bestOf = 3 // Default value
+		if roundIndex >= 0 && roundIndex < len(roundOptions) {
+			bestOf = parseInt(roundOptions[roundIndex])
+		}
 	}
 
-	game := NewGame(bestOf, secretMode)
-	fmt.Printf("Playing best of %d games\n", bestOf)
+	game := NewGame(bestOf, secretMode, rng)
+	out.Printf("Playing best of %d games\n", bestOf)
 	if secretMode {
-		fmt.Println("🖖 Secret mode activated: Rock Paper Scissors Lizard Spock!")
+		out.Println("🖖 Secret mode activated: Rock Paper Scissors Lizard Spock!")
 	}
 
+	roundsWon := 0
 	for !game.GameOver {
-		fmt.Printf("\nCurrent score - Player: %d, Computer: %d\n", game.PlayerScore, game.ComputerScore)
+		out.Printf("\nCurrent score - Player: %d, Computer: %d\n", game.PlayerScore, game.ComputerScore)
 
 		options := standardOptions
 		if secretMode {
@@ -190,20 +205,52 @@ func PlayGame(prompter Prompter, secretMode bool) {
 		// Get player choice using prompter
 		playerChoiceIndex, err := prompter.Select("Choose your move", "rock", options)
 		if err != nil {
-			fmt.Printf("Error getting player choice: %v\n", err)
-			return
+			out.Printf("Error getting player choice: %v\n", err)
+			return result
 		}
 		playerChoice := options[playerChoiceIndex]
 
 		game.Play(playerChoice)
 		if playerChoice == "exit" {
-			break
+			out.Println(game.GameOverMessage)
+			return result
 		}
 
+		if game.Winner == "player" {
+			roundsWon++
+			result.Streak = max(result.Streak, roundsWon)
+		} else {
+			roundsWon = 0
+		}
+
+		out.Event("computer_choice", output.Fields{
+			"player":         game.PlayerChoice,
+			"computer":       game.ComputerChoice,
+			"winner":         game.Winner,
+			"player_score":   game.PlayerScore,
+			"computer_score": game.ComputerScore,
+		})
+
 		// Display a more concise round result
-		fmt.Println(game.getRoundResultMessage())
+		out.Println(game.getRoundResultMessage())
+	}
+	out.Event("series_over", output.Fields{
+		"best_of":        bestOf,
+		"player_score":   game.PlayerScore,
+		"computer_score": game.ComputerScore,
+	})
+	out.Println(game.GameOverMessage)
+
+	result.Played = 1
+	switch {
+	case game.PlayerScore > game.ComputerScore:
+		result.Wins = 1
+	case game.ComputerScore > game.PlayerScore:
+		result.Losses = 1
+	default:
+		result.Draws = 1
 	}
-	fmt.Println(game.GameOverMessage)
+	return result
 }
 
 // parseInt safely converts a string to an integer
@@ -2,10 +2,18 @@ package rockpaperscissors
 
 import (
 	"fmt"
+	"math/rand"
 	"strings"
 	"testing"
+
+	"github.com/chrisreddington/gh-game/internal/output"
 )
 
+// newTestRand returns a deterministic random source for tests.
+func newTestRand() *rand.Rand {
+	return rand.New(rand.NewSource(1))
+}
+
 func TestNewGame(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -47,7 +55,7 @@ func TestNewGame(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			game := NewGame(tt.bestOf, tt.secretMode)
+			game := NewGame(tt.bestOf, tt.secretMode, newTestRand())
 			if game.BestOf != tt.wantBestOf {
 				t.Errorf("NewGame() BestOf = %v, want %v", game.BestOf, tt.wantBestOf)
 			}
@@ -309,7 +317,7 @@ func TestGame_Play(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			g := NewGame(tt.bestOf, tt.secretMode)
+			g := NewGame(tt.bestOf, tt.secretMode, newTestRand())
 			for _, move := range tt.moves {
 				g.Play(move)
 			}
@@ -570,11 +578,41 @@ func TestPlayGame(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			PlayGame(tt.prompter, tt.secretMode)
+			PlayGame(tt.prompter, output.Discard, newTestRand(), 0, tt.secretMode)
 		})
 	}
 }
 
+func TestPlayGame_AbandonedSeriesIsNotCounted(t *testing.T) {
+	mockPrompt := &mockPromptSequence{
+		returns: []int{0, 3}, // Select 3 rounds, then exit
+		errors:  []error{nil, nil},
+	}
+
+	if got := PlayGame(mockPrompt, output.Discard, newTestRand(), 0, false); got.Played != 0 {
+		t.Errorf("PlayGame() Played = %d, want 0 for an abandoned series", got.Played)
+	}
+}
+
+// movesOnlyPrompter always plays rock and fails if asked for the number of rounds
+type movesOnlyPrompter struct {
+	t *testing.T
+}
+
+func (m movesOnlyPrompter) Select(prompt, defaultValue string, options []string) (int, error) {
+	if prompt != "Choose your move" {
+		m.t.Fatalf("unexpected prompt %q", prompt)
+	}
+	return 0, nil
+}
+
+func TestPlayGame_GivenRoundsSkipsPrompt(t *testing.T) {
+	got := PlayGame(movesOnlyPrompter{t}, output.Discard, newTestRand(), 1, false)
+	if got.Played != 1 || got.Wins+got.Losses != 1 {
+		t.Errorf("PlayGame() = %+v, want a single decided series", got)
+	}
+}
+
 func TestParseInt(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -625,6 +663,7 @@ func TestGame_getComputerChoice(t *testing.T) {
 	// Test standard mode
 	g1 := &Game{
 		SecretMode: false,
+		rng:        newTestRand(),
 	}
 
 	standardChoices := map[string]bool{
@@ -652,6 +691,7 @@ func TestGame_getComputerChoice(t *testing.T) {
 	// Test secret mode
 	g2 := &Game{
 		SecretMode: true,
+		rng:        newTestRand(),
 	}
 
 	secretChoices := map[string]bool{
@@ -672,6 +712,17 @@ func TestGame_getComputerChoice(t *testing.T) {
 	}
 }
 
+func TestGame_getComputerChoice_SameSeedSameChoices(t *testing.T) {
+	first := NewGame(3, true, rand.New(rand.NewSource(42)))
+	second := NewGame(3, true, rand.New(rand.NewSource(42)))
+
+	for i := 0; i < 20; i++ {
+		if a, b := first.getComputerChoice(), second.getComputerChoice(); a != b {
+			t.Fatalf("choice %d differs between games with the same seed: %q vs %q", i, a, b)
+		}
+	}
+}
+
 func TestPlayGame_EnhancedCoverage(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -706,7 +757,7 @@ func TestPlayGame_EnhancedCoverage(t *testing.T) {
 				errors:  tt.errors,
 			}
 
-			PlayGame(mockPrompt, tt.secretMode)
+			PlayGame(mockPrompt, output.Discard, newTestRand(), 0, tt.secretMode)
 		})
 	}
 }
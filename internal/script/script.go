@@ -0,0 +1,148 @@
+// Package script answers game prompts from a list of moves instead of a
+// terminal, so games can run headless in CI demos and integration tests.
+package script
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrNoMoreMoves is returned when a game prompts after the script has run out.
+var ErrNoMoreMoves = errors.New("no more scripted moves")
+
+// Prompter answers prompts with the moves read from a script, one per line.
+// Blank lines and lines starting with # are skipped.
+//
+// Select answers match option labels case-insensitively, or by a prefix that
+// only one option starts with, so "tails", "higher", "spock", "5" and "e" are
+// all valid moves. Confirm answers are yes/no, y/n or true/false. Input answers
+// are used as they are.
+type Prompter struct {
+	scanner *bufio.Scanner
+	line    int
+	echo    io.Writer
+	err     error
+}
+
+// New creates a Prompter that reads moves from r. When echo is not nil, every
+// prompt is written to it along with the scripted answer, so a headless game
+// still reads like an interactive one.
+func New(r io.Reader, echo io.Writer) *Prompter {
+	return &Prompter{
+		scanner: bufio.NewScanner(r),
+		echo:    echo,
+	}
+}
+
+// Err returns the first error the script ran into, such as a move that
+// matched no option or running out of moves.
+func (p *Prompter) Err() error {
+	return p.err
+}
+
+// Select implements the games' prompter interface.
+func (p *Prompter) Select(prompt string, defaultValue string, options []string) (int, error) {
+	answer, err := p.next(prompt)
+	if err != nil {
+		return -1, err
+	}
+
+	index, err := matchOption(answer, options)
+	if err != nil {
+		return -1, p.fail(fmt.Errorf("line %d: %w for %q", p.line, err, prompt))
+	}
+	p.show(prompt, options[index])
+	return index, nil
+}
+
+// Input implements the games' prompter interface.
+func (p *Prompter) Input(prompt string, defaultValue string) (string, error) {
+	answer, err := p.next(prompt)
+	if err != nil {
+		return "", err
+	}
+	p.show(prompt, answer)
+	return answer, nil
+}
+
+// Confirm implements the games' prompter interface.
+func (p *Prompter) Confirm(prompt string, defaultValue bool) (bool, error) {
+	answer, err := p.next(prompt)
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(answer) {
+	case "y", "yes", "true":
+		p.show(prompt, "Yes")
+		return true, nil
+	case "n", "no", "false":
+		p.show(prompt, "No")
+		return false, nil
+	}
+	return false, p.fail(fmt.Errorf("line %d: %q is not yes or no for %q", p.line, answer, prompt))
+}
+
+// next reads the next move from the script.
+func (p *Prompter) next(prompt string) (string, error) {
+	if p.err != nil {
+		return "", p.err
+	}
+
+	for p.scanner.Scan() {
+		p.line++
+		move := strings.TrimSpace(p.scanner.Text())
+		if move == "" || strings.HasPrefix(move, "#") {
+			continue
+		}
+		return move, nil
+	}
+
+	if err := p.scanner.Err(); err != nil {
+		return "", p.fail(fmt.Errorf("reading moves: %w", err))
+	}
+	return "", p.fail(fmt.Errorf("%w: the game asked %q", ErrNoMoreMoves, prompt))
+}
+
+// fail remembers the first error so it can be reported once the game ends.
+func (p *Prompter) fail(err error) error {
+	if p.err == nil {
+		p.err = err
+	}
+	return err
+}
+
+// show echoes a prompt and its answer.
+func (p *Prompter) show(prompt, answer string) {
+	if p.echo != nil {
+		fmt.Fprintf(p.echo, "? %s %s\n", strings.TrimSpace(prompt), answer)
+	}
+}
+
+// matchOption finds the option a move refers to. An exact match wins over a
+// prefix match, and a prefix must only match a single option.
+func matchOption(move string, options []string) (int, error) {
+	move = strings.ToLower(move)
+	for i, option := range options {
+		if strings.ToLower(option) == move {
+			return i, nil
+		}
+	}
+
+	match := -1
+	for i, option := range options {
+		if strings.HasPrefix(strings.ToLower(option), move) {
+			if match >= 0 {
+				return -1, fmt.Errorf("%q matches both %q and %q", move, options[match], option)
+			}
+			match = i
+		}
+	}
+	if match < 0 {
+		return -1, fmt.Errorf("%q matches none of %s", move, strings.Join(options, ", "))
+	}
+	return match, nil
+}
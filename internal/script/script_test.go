@@ -0,0 +1,120 @@
+package script
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSelect(t *testing.T) {
+	options := []string{"rock", "paper", "scissors", "lizard", "Spock", "exit"}
+
+	tests := []struct {
+		name      string
+		move      string
+		wantIndex int
+		wantErr   string
+	}{
+		{name: "exact label", move: "paper", wantIndex: 1},
+		{name: "case-insensitive", move: "spock", wantIndex: 4},
+		{name: "unique prefix", move: "sc", wantIndex: 2},
+		{name: "surrounding space", move: "  lizard  ", wantIndex: 3},
+		{name: "no match", move: "dynamite", wantIndex: -1, wantErr: `line 1: "dynamite" matches none of rock, paper, scissors, lizard, Spock, exit for "Choose your move"`},
+		{name: "ambiguous prefix", move: "s", wantIndex: -1, wantErr: `line 1: "s" matches both "scissors" and "Spock" for "Choose your move"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(strings.NewReader(tt.move+"\n"), nil)
+			index, err := p.Select("Choose your move", "rock", options)
+
+			if index != tt.wantIndex {
+				t.Errorf("Select() index = %d, want %d", index, tt.wantIndex)
+			}
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Select() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Select() error = %v, want %q", err, tt.wantErr)
+			}
+			if p.Err() != err {
+				t.Errorf("Err() = %v, want %v", p.Err(), err)
+			}
+		})
+	}
+}
+
+func TestSelectExactBeatsPrefix(t *testing.T) {
+	p := New(strings.NewReader("1\n"), nil)
+	index, err := p.Select("Select position (1-9):", "1", []string{"1", "10", "11"})
+	if err != nil || index != 0 {
+		t.Errorf("Select() = %d, %v, want 0, nil", index, err)
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		move    string
+		want    bool
+		wantErr bool
+	}{
+		{move: "y", want: true},
+		{move: "Yes", want: true},
+		{move: "true", want: true},
+		{move: "n", want: false},
+		{move: "NO", want: false},
+		{move: "maybe", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.move, func(t *testing.T) {
+			p := New(strings.NewReader(tt.move), nil)
+			got, err := p.Confirm("Play again?", true)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Confirm() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Confirm() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScript(t *testing.T) {
+	moves := `# a full word guess session
+e
+
+  g
+n
+`
+	var echo bytes.Buffer
+	p := New(strings.NewReader(moves), &echo)
+
+	for _, want := range []string{"e", "g"} {
+		got, err := p.Input("Enter a letter: ", "")
+		if err != nil || got != want {
+			t.Fatalf("Input() = %q, %v, want %q, nil", got, err, want)
+		}
+	}
+	again, err := p.Confirm("Play again?", true)
+	if err != nil || again {
+		t.Fatalf("Confirm() = %v, %v, want false, nil", again, err)
+	}
+
+	_, err = p.Input("Enter a letter: ", "")
+	if !errors.Is(err, ErrNoMoreMoves) {
+		t.Errorf("Input() after the last move error = %v, want ErrNoMoreMoves", err)
+	}
+	if !errors.Is(p.Err(), ErrNoMoreMoves) {
+		t.Errorf("Err() = %v, want ErrNoMoreMoves", p.Err())
+	}
+
+	wantEcho := "? Enter a letter: e\n? Enter a letter: g\n? Play again? No\n"
+	if echo.String() != wantEcho {
+		t.Errorf("echo = %q, want %q", echo.String(), wantEcho)
+	}
+}
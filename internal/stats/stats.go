@@ -0,0 +1,175 @@
+// Package stats keeps a local record of every game played so that streaks,
+// wins and personal bests survive after a game exits.
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/chrisreddington/gh-game/internal/configdir"
+)
+
+// FileName is the name of the statistics file inside the gh-game config directory.
+const FileName = "stats.json"
+
+// Result summarises what happened during one call to a game's PlayGame.
+// Every completed game counts once in Played and once in exactly one of Wins,
+// Losses or Draws, so Wins+Losses+Draws always equals Played. Rounds within a
+// game only count towards Streak.
+type Result struct {
+	Played int // Played is the number of games completed during the session
+	Wins   int // Wins is the number of games the player won
+	Losses int // Losses is the number of games the player lost
+	Draws  int // Draws is the number of games that ended level
+	Streak int // Streak is the longest run of wins reached during the session
+}
+
+// GameStats holds the accumulated statistics for a single game.
+type GameStats struct {
+	Played       int       `json:"played"`
+	Wins         int       `json:"wins"`
+	Losses       int       `json:"losses"`
+	Draws        int       `json:"draws"`
+	BestStreak   int       `json:"bestStreak"`
+	BestStreakAt time.Time `json:"bestStreakAt,omitzero"`
+	FirstPlayed  time.Time `json:"firstPlayed"`
+	LastPlayed   time.Time `json:"lastPlayed"`
+}
+
+// Store is the collection of statistics for every game, backed by a JSON file.
+type Store struct {
+	path  string
+	Games map[string]*GameStats `json:"games"`
+}
+
+// DefaultPath returns the location of the statistics file in the gh-game config directory.
+func DefaultPath() string {
+	return filepath.Join(configdir.Dir(), FileName)
+}
+
+// Load reads the statistics stored at path.
+// A missing file is not an error and results in an empty store.
+func Load(path string) (*Store, error) {
+	store := &Store{path: path, Games: map[string]*GameStats{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading statistics: %w", err)
+	}
+
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("parsing statistics in %s: %w", path, err)
+	}
+	if store.Games == nil {
+		store.Games = map[string]*GameStats{}
+	}
+	return store, nil
+}
+
+// Record adds the result of a finished session of game to the store.
+// Sessions in which no game was completed are ignored.
+func (s *Store) Record(game string, result Result, at time.Time) {
+	if result.Played <= 0 {
+		return
+	}
+
+	stats, ok := s.Games[game]
+	if !ok {
+		stats = &GameStats{FirstPlayed: at}
+		s.Games[game] = stats
+	}
+
+	stats.Played += result.Played
+	stats.Wins += result.Wins
+	stats.Losses += result.Losses
+	stats.Draws += result.Draws
+	stats.LastPlayed = at
+	if result.Streak > stats.BestStreak {
+		stats.BestStreak = result.Streak
+		stats.BestStreakAt = at
+	}
+}
+
+// Get returns the statistics recorded for game.
+func (s *Store) Get(game string) (GameStats, bool) {
+	stats, ok := s.Games[game]
+	if !ok {
+		return GameStats{}, false
+	}
+	return *stats, true
+}
+
+// Names returns the names of every game with recorded statistics, sorted alphabetically.
+func (s *Store) Names() []string {
+	names := make([]string, 0, len(s.Games))
+	for name := range s.Games {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Totals returns the statistics of every game added together.
+// The best streak is the best across all games.
+func (s *Store) Totals() GameStats {
+	var totals GameStats
+	for _, stats := range s.Games {
+		totals.Played += stats.Played
+		totals.Wins += stats.Wins
+		totals.Losses += stats.Losses
+		totals.Draws += stats.Draws
+		if stats.BestStreak > totals.BestStreak {
+			totals.BestStreak = stats.BestStreak
+			totals.BestStreakAt = stats.BestStreakAt
+		}
+		if totals.FirstPlayed.IsZero() || stats.FirstPlayed.Before(totals.FirstPlayed) {
+			totals.FirstPlayed = stats.FirstPlayed
+		}
+		if stats.LastPlayed.After(totals.LastPlayed) {
+			totals.LastPlayed = stats.LastPlayed
+		}
+	}
+	return totals
+}
+
+// Save writes the store back to the file it was loaded from, creating the
+// directory if needed. The file is replaced atomically so an interrupted
+// write never leaves corrupt statistics behind.
+func (s *Store) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding statistics: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating statistics directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, FileName+".*")
+	if err != nil {
+		return fmt.Errorf("writing statistics: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing statistics: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing statistics: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("writing statistics: %w", err)
+	}
+	return nil
+}
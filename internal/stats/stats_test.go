@@ -0,0 +1,117 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoad_MissingFile(t *testing.T) {
+	store, err := Load(filepath.Join(t.TempDir(), FileName))
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if len(store.Games) != 0 {
+		t.Errorf("Load() of missing file has %d games, want 0", len(store.Games))
+	}
+}
+
+func TestLoad_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatalf("writing test file: %v", err)
+	}
+
+	if _, err := Load(path); err == nil {
+		t.Error("Load() of invalid file did not return an error")
+	}
+}
+
+func TestRecord(t *testing.T) {
+	first := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+	third := second.Add(time.Hour)
+
+	store := &Store{Games: map[string]*GameStats{}}
+	store.Record("cointoss", Result{Played: 1, Losses: 1, Streak: 3}, first)
+	store.Record("cointoss", Result{Played: 1, Wins: 1, Streak: 5}, second)
+	store.Record("cointoss", Result{Played: 1, Losses: 1, Streak: 1}, third)
+
+	got, ok := store.Get("cointoss")
+	if !ok {
+		t.Fatal("Get() did not find recorded game")
+	}
+
+	want := GameStats{
+		Played:       3,
+		Wins:         1,
+		Losses:       2,
+		BestStreak:   5,
+		BestStreakAt: second,
+		FirstPlayed:  first,
+		LastPlayed:   third,
+	}
+	if got != want {
+		t.Errorf("Get() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRecord_IgnoresEmptySessions(t *testing.T) {
+	store := &Store{Games: map[string]*GameStats{}}
+	store.Record("wordguess", Result{}, time.Now())
+
+	if _, ok := store.Get("wordguess"); ok {
+		t.Error("Record() stored a session in which no game was completed")
+	}
+}
+
+func TestTotals(t *testing.T) {
+	early := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	late := early.Add(24 * time.Hour)
+
+	store := &Store{Games: map[string]*GameStats{}}
+	store.Record("higherlower", Result{Played: 1, Losses: 1, Streak: 7}, early)
+	store.Record("tictactoe", Result{Played: 1, Draws: 1}, late)
+
+	totals := store.Totals()
+	if totals.Played != 2 || totals.Wins != 0 || totals.Losses != 1 || totals.Draws != 1 {
+		t.Errorf("Totals() counts = %+v, want played 2, wins 0, losses 1, draws 1", totals)
+	}
+	if totals.BestStreak != 7 {
+		t.Errorf("Totals() BestStreak = %d, want 7", totals.BestStreak)
+	}
+	if !totals.FirstPlayed.Equal(early) || !totals.LastPlayed.Equal(late) {
+		t.Errorf("Totals() played between %v and %v, want %v and %v",
+			totals.FirstPlayed, totals.LastPlayed, early, late)
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", FileName)
+	at := time.Date(2025, 3, 14, 9, 26, 0, 0, time.UTC)
+
+	store, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	store.Record("rockpaperscissors", Result{Played: 1, Wins: 1, Streak: 2}, at)
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+
+	reloaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() after Save() unexpected error: %v", err)
+	}
+	got, ok := reloaded.Get("rockpaperscissors")
+	if !ok {
+		t.Fatal("reloaded store is missing recorded game")
+	}
+	if got.Wins != 1 || got.BestStreak != 2 || !got.LastPlayed.Equal(at) {
+		t.Errorf("reloaded stats = %+v, want wins 1, best streak 2, last played %v", got, at)
+	}
+	if names := reloaded.Names(); len(names) != 1 || names[0] != "rockpaperscissors" {
+		t.Errorf("Names() = %v, want [rockpaperscissors]", names)
+	}
+}
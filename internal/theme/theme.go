@@ -0,0 +1,198 @@
+// Package theme defines the colours used across gh-game by what they mean
+// rather than what they look like. Games render text with a semantic Role,
+// and the current Theme decides how each role is styled, so players can pick
+// a palette that works for their eyes and their terminal.
+package theme
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Role is the meaning of a piece of styled text.
+type Role string
+
+const (
+	Title     Role = "title"     // Game titles and headings
+	Highlight Role = "highlight" // Important values such as numbers and words
+	Success   Role = "success"   // Correct guesses and wins
+	Failure   Role = "failure"   // Wrong guesses, losses and errors
+	Accent    Role = "accent"    // The player's choices and instructions
+	Score     Role = "score"     // Streaks and scores
+	Player1   Role = "player1"   // The first player, such as X in tic-tac-toe
+	Player2   Role = "player2"   // The second player, such as O in tic-tac-toe
+	Muted     Role = "muted"     // Secondary text such as descriptions and hints
+	Selected  Role = "selected"  // The item under the cursor in a menu
+	Text      Role = "text"      // Plain text that should stand out from muted text
+)
+
+// Roles lists every role.
+var Roles = []Role{Title, Highlight, Success, Failure, Accent, Score, Player1, Player2, Muted, Selected, Text}
+
+// Theme maps every role to a style.
+type Theme struct {
+	Name        string
+	Description string
+	Styles      map[Role]lipgloss.Style
+}
+
+// Style returns the style the theme uses for role.
+func (t *Theme) Style(role Role) lipgloss.Style {
+	return t.Styles[role]
+}
+
+// DefaultName is the name of the theme used unless another is chosen.
+const DefaultName = "default"
+
+// fg is a style with a foreground colour.
+func fg(color string) lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+}
+
+var (
+	// Default is the original gh-game palette.
+	Default = &Theme{
+		Name:        DefaultName,
+		Description: "The original gh-game colours",
+		Styles: map[Role]lipgloss.Style{
+			Title:     fg("99").Bold(true),  // purple
+			Highlight: fg("39").Bold(true),  // blue
+			Success:   fg("10"),             // green
+			Failure:   fg("9"),              // red
+			Accent:    fg("220"),            // yellow
+			Score:     fg("208").Bold(true), // orange
+			Player1:   fg("45"),             // bright blue
+			Player2:   fg("226"),            // bright yellow
+			Muted:     fg("245"),            // grey
+			Selected:  fg("212").Bold(true), // pink
+			Text:      fg("15"),             // white
+		},
+	}
+
+	// HighContrast uses bold, bright colours from the basic 16-colour palette,
+	// which every terminal can show and most themes keep legible.
+	HighContrast = &Theme{
+		Name:        "high-contrast",
+		Description: "Bold, bright colours for low-vision players and washed-out screens",
+		Styles: map[Role]lipgloss.Style{
+			Title:     fg("15").Bold(true).Underline(true),
+			Highlight: fg("14").Bold(true),
+			Success:   fg("10").Bold(true),
+			Failure:   fg("9").Bold(true),
+			Accent:    fg("11").Bold(true),
+			Score:     fg("13").Bold(true),
+			Player1:   fg("14").Bold(true),
+			Player2:   fg("11").Bold(true),
+			Muted:     fg("7"),
+			Selected:  lipgloss.NewStyle().Bold(true).Reverse(true),
+			Text:      fg("15").Bold(true),
+		},
+	}
+
+	// ColorBlind uses the Okabe-Ito palette, whose colours stay distinct for
+	// people with deuteranopia or protanopia. Success and failure are never
+	// told apart by red and green alone.
+	ColorBlind = &Theme{
+		Name:        "colorblind",
+		Description: "Okabe-Ito colours that stay distinct with deuteranopia and protanopia",
+		Styles: map[Role]lipgloss.Style{
+			Title:     fg("#CC79A7").Bold(true),      // reddish purple
+			Highlight: fg("#56B4E9").Bold(true),      // sky blue
+			Success:   fg("#009E73").Bold(true),      // bluish green
+			Failure:   fg("#D55E00").Underline(true), // vermillion
+			Accent:    fg("#F0E442"),                 // yellow
+			Score:     fg("#E69F00").Bold(true),      // orange
+			Player1:   fg("#56B4E9").Bold(true),      // sky blue
+			Player2:   fg("#E69F00").Bold(true),      // orange
+			Muted:     fg("245"),                     // grey
+			Selected:  fg("#CC79A7").Bold(true),      // reddish purple
+			Text:      fg("15"),                      // white
+		},
+	}
+
+	// Monochrome uses no colour at all, telling roles apart with bold,
+	// underlined and faint text. It is used when NO_COLOR is set.
+	Monochrome = &Theme{
+		Name:        "monochrome",
+		Description: "No colour, only bold, underlined and faint text",
+		Styles: map[Role]lipgloss.Style{
+			Title:     lipgloss.NewStyle().Bold(true).Underline(true),
+			Highlight: lipgloss.NewStyle().Bold(true),
+			Success:   lipgloss.NewStyle().Bold(true),
+			Failure:   lipgloss.NewStyle().Underline(true),
+			Accent:    lipgloss.NewStyle().Italic(true),
+			Score:     lipgloss.NewStyle().Bold(true),
+			Player1:   lipgloss.NewStyle().Bold(true),
+			Player2:   lipgloss.NewStyle().Underline(true),
+			Muted:     lipgloss.NewStyle().Faint(true),
+			Selected:  lipgloss.NewStyle().Reverse(true),
+			Text:      lipgloss.NewStyle(),
+		},
+	}
+)
+
+// themes holds every built-in theme by name
+var themes = map[string]*Theme{}
+
+// current is the theme roles are rendered with
+var current = Default
+
+func init() {
+	for _, theme := range []*Theme{Default, HighContrast, ColorBlind, Monochrome} {
+		themes[theme.Name] = theme
+	}
+}
+
+// Lookup returns the built-in theme called name.
+func Lookup(name string) (*Theme, bool) {
+	theme, ok := themes[name]
+	return theme, ok
+}
+
+// Names returns the names of the built-in themes in alphabetical order.
+func Names() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Current returns the theme in use.
+func Current() *Theme {
+	return current
+}
+
+// Use makes theme the one every role is rendered with.
+func Use(theme *Theme) {
+	current = theme
+}
+
+// Resolve picks the theme to use. A theme the player chose explicitly is
+// always used; otherwise NO_COLOR selects Monochrome, following
+// https://no-color.org.
+func Resolve(name string, chosen bool) (*Theme, error) {
+	theme, ok := Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown theme %q: choose one of %s", name, strings.Join(Names(), ", "))
+	}
+	if !chosen && os.Getenv("NO_COLOR") != "" {
+		return Monochrome, nil
+	}
+	return theme, nil
+}
+
+// Style returns the current theme's style for the role.
+func (r Role) Style() lipgloss.Style {
+	return current.Style(r)
+}
+
+// Render styles text with the current theme's style for the role.
+func (r Role) Render(strs ...string) string {
+	return r.Style().Render(strs...)
+}
@@ -0,0 +1,79 @@
+package theme
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestThemesDefineEveryRole(t *testing.T) {
+	for _, name := range Names() {
+		theme, _ := Lookup(name)
+		for _, role := range Roles {
+			if _, ok := theme.Styles[role]; !ok {
+				t.Errorf("theme %q has no style for %q", name, role)
+			}
+		}
+	}
+}
+
+func TestNames(t *testing.T) {
+	want := []string{"colorblind", "default", "high-contrast", "monochrome"}
+	if got := Names(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+}
+
+func TestMonochromeHasNoColours(t *testing.T) {
+	for role, style := range Monochrome.Styles {
+		if _, ok := style.GetForeground().(lipgloss.NoColor); !ok {
+			t.Errorf("monochrome style for %q has a foreground colour", role)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name    string
+		theme   string
+		chosen  bool
+		noColor string
+		want    *Theme
+		wantErr bool
+	}{
+		{name: "default", theme: DefaultName, want: Default},
+		{name: "chosen theme", theme: "high-contrast", chosen: true, want: HighContrast},
+		{name: "NO_COLOR replaces the default", theme: DefaultName, noColor: "1", want: Monochrome},
+		{name: "chosen theme wins over NO_COLOR", theme: "colorblind", chosen: true, noColor: "1", want: ColorBlind},
+		{name: "unknown theme", theme: "neon", chosen: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+
+			got, err := Resolve(tt.theme, tt.chosen)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoleUsesCurrentTheme(t *testing.T) {
+	defer Use(Current())
+
+	for _, theme := range []*Theme{Default, Monochrome} {
+		Use(theme)
+		if got, want := Failure.Style(), theme.Styles[Failure]; !reflect.DeepEqual(got, want) {
+			t.Errorf("Failure.Style() with %s theme = %v, want %v", theme.Name, got, want)
+		}
+		if got, want := Failure.Render("lose"), theme.Styles[Failure].Render("lose"); got != want {
+			t.Errorf("Failure.Render() with %s theme = %q, want %q", theme.Name, got, want)
+		}
+	}
+}
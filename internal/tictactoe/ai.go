@@ -0,0 +1,402 @@
+package tictactoe
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Difficulty sets how well the computer opponent plays.
+// The zero value is Perfect.
+type Difficulty int
+
+const (
+	// Perfect searches the whole game tree and never loses
+	Perfect Difficulty = iota
+	// Hard searches the whole game tree but occasionally plays a random move
+	Hard
+	// Medium looks two moves ahead, so it wins and blocks but misses forks
+	Medium
+	// Easy only spots its own winning moves and often plays at random
+	Easy
+)
+
+// difficultyNames maps each difficulty to the name used on the command line
+var difficultyNames = map[Difficulty]string{
+	Easy:    "easy",
+	Medium:  "medium",
+	Hard:    "hard",
+	Perfect: "perfect",
+}
+
+// DifficultyNames lists the difficulty names from easiest to hardest.
+func DifficultyNames() []string {
+	return []string{"easy", "medium", "hard", "perfect"}
+}
+
+// ParseDifficulty returns the difficulty with the given name.
+func ParseDifficulty(name string) (Difficulty, error) {
+	for difficulty, difficultyName := range difficultyNames {
+		if strings.EqualFold(name, difficultyName) {
+			return difficulty, nil
+		}
+	}
+	return Perfect, fmt.Errorf("invalid difficulty %q: must be one of %s", name, strings.Join(DifficultyNames(), ", "))
+}
+
+// String returns the name of the difficulty.
+func (d Difficulty) String() string {
+	return difficultyNames[d]
+}
+
+// Level tunes how the computer plays at a difficulty.
+type Level struct {
+	Depth       int     // Depth is how many moves ahead to search, or zero for the whole game
+	MistakeRate float64 // MistakeRate is the chance of playing a random move instead of the best one
+}
+
+// Levels holds the tuning for each difficulty.
+var Levels = map[Difficulty]Level{
+	Easy:    {Depth: 1, MistakeRate: 0.4},
+	Medium:  {Depth: 2, MistakeRate: 0.15},
+	Hard:    {Depth: 0, MistakeRate: 0.1},
+	Perfect: {Depth: 0, MistakeRate: 0},
+}
+
+// winScore is the value of a won position. Wins found sooner score higher,
+// so the computer takes a win straight away and puts off a loss. It is far
+// larger than any score evaluate gives a position that is still open.
+const winScore = 1 << 30
+
+// largeBoardDepth returns how many moves ahead the computer can search on a
+// board with more than nine squares, where searching the whole game is too
+// slow. Bigger boards have more moves to consider, so they are searched less
+// deeply and the search relies on evaluate instead.
+func largeBoardDepth(cells int) int {
+	switch {
+	case cells <= 16:
+		return 4
+	case cells <= 49:
+		return 3
+	default:
+		return 2
+	}
+}
+
+// GetComputerMove chooses the computer's next move with a minimax search of
+// the game tree, pruned with alpha-beta. How far it looks and how often it
+// plays a random move instead depend on the game's Difficulty. On boards
+// larger than 3x3 the search is cut off after a few moves, only considers
+// squares next to ones already played and scores the positions it reaches
+// by the lines each side can still complete.
+// Among equally good moves it prefers the center, then a random corner, then
+// the first free square. In Wild it also chooses the mark to place, which the
+// next call to MakeMove places.
+// Returns row and column indices for the chosen move, or (-1, -1) if the
+// board is full.
+func (g *Game) GetComputerMove() (rowIndex, columnIndex int) {
+	return g.chooseMove(g.ComputerMark, g.Difficulty)
+}
+
+// SuggestMove chooses a move for the player about to move the way the
+// computer would at the given difficulty, whoever that player is.
+// Returns (-1, -1) if the board is full.
+func (g *Game) SuggestMove(difficulty Difficulty) (rowIndex, columnIndex int) {
+	return g.chooseMove(g.CurrentPlayer, difficulty)
+}
+
+// chooseMove searches for the best move for player at the given difficulty,
+// and in Wild chooses the mark it places.
+func (g *Game) chooseMove(player string, difficulty Difficulty) (rowIndex, columnIndex int) {
+	moves := g.orderedMoves()
+	if len(moves) == 0 {
+		return -1, -1
+	}
+	placements := g.placements(player)
+
+	level := Levels[difficulty]
+	if level.MistakeRate > 0 && g.rng.Float64() < level.MistakeRate {
+		move := moves[g.rng.Intn(len(moves))]
+		g.choosePlacement(placements[g.rng.Intn(len(placements))])
+		return move[0], move[1]
+	}
+
+	depth := level.Depth
+	if g.UsesCoordinates() {
+		limit := largeBoardDepth(g.Size() * g.Size())
+		if depth == 0 || depth > limit {
+			depth = limit
+		}
+	}
+
+	best, bestMark := moves[0], placements[0]
+	bestScore := -2 * winScore
+	for _, move := range moves {
+		for _, placed := range placements {
+			score := g.scoreMove(move, player, placed, 1, depth, bestScore, 2*winScore)
+			if score > bestScore {
+				best, bestMark, bestScore = move, placed, score
+			}
+		}
+	}
+	g.choosePlacement(bestMark)
+	return best[0], best[1]
+}
+
+// placements lists the marks player may place: both in Wild, otherwise their
+// own.
+func (g *Game) placements(player string) []string {
+	if g.ChoosesMark() {
+		return []string{player, switchPlayer(player)}
+	}
+	return []string{player}
+}
+
+// choosePlacement sets the mark the next move places in Wild.
+func (g *Game) choosePlacement(mark string) {
+	if g.ChoosesMark() {
+		g.nextMark = mark
+	}
+}
+
+// scoreMove has player place the placed mark at move, scores the result for
+// that player and takes the move back. Completing a line wins, except in
+// Misère where it loses.
+func (g *Game) scoreMove(move [2]int, player, placed string, ply, depth, alpha, beta int) int {
+	g.board[move[0]][move[1]] = placed
+	defer func() { g.board[move[0]][move[1]] = "" }()
+
+	if g.completesLine(move[0], move[1]) {
+		if g.Variant == Misere {
+			return -(winScore - ply)
+		}
+		return winScore - ply
+	}
+	return -g.negamax(switchPlayer(player), ply+1, depth, -beta, -alpha)
+}
+
+// negamax scores the position for the player about to move, searching up to
+// depth moves ahead in total (or to the end of the game if depth is zero).
+// ply counts the moves already made in the search. Positions past the search
+// depth are scored by evaluate.
+func (g *Game) negamax(player string, ply, depth, alpha, beta int) int {
+	if g.IsBoardFull() {
+		return 0
+	}
+	if depth > 0 && ply > depth {
+		return g.evaluate(player)
+	}
+
+	best := -2 * winScore
+	for _, move := range g.candidateMoves() {
+		for _, placed := range g.placements(player) {
+			score := g.scoreMove(move, player, placed, ply, depth, alpha, beta)
+			best = max(best, score)
+			alpha = max(alpha, score)
+			if alpha >= beta {
+				return best
+			}
+		}
+	}
+	return best
+}
+
+// evaluate scores an unfinished position for the player about to move by the
+// lines each side could still complete. In Misère those lines count against
+// their owner, and in Wild, where either player can finish any line, every
+// position is scored as even. On a 3x3 board every position is scored as
+// even, since the search always reaches the end.
+func (g *Game) evaluate(player string) int {
+	if !g.UsesCoordinates() {
+		return 0
+	}
+	switch g.Variant {
+	case Misere:
+		return -g.board.lineScore(player, g.winLength())
+	case Wild:
+		return 0
+	default:
+		return g.board.lineScore(player, g.winLength())
+	}
+}
+
+// lineScore scores the board for mark by counting the lines of winLength
+// squares that each side could still complete. A line is worth more the more
+// of its squares are already taken, and nothing once both sides have a mark
+// in it.
+func (b Board) lineScore(mark string, winLength int) int {
+	score := 0
+	for rowIndex := range b {
+		for columnIndex := range b[rowIndex] {
+			for _, direction := range lineDirections {
+				endRow := rowIndex + direction[0]*(winLength-1)
+				endColumn := columnIndex + direction[1]*(winLength-1)
+				if !b.onBoard(endRow, endColumn) {
+					continue
+				}
+				own, other := 0, 0
+				for step := range winLength {
+					switch b[rowIndex+direction[0]*step][columnIndex+direction[1]*step] {
+					case "":
+					case mark:
+						own++
+					default:
+						other++
+					}
+				}
+				switch {
+				case other == 0 && own > 0:
+					score += lineValue(winLength - own)
+				case own == 0 && other > 0:
+					score -= lineValue(winLength - other)
+				}
+			}
+		}
+	}
+	return score
+}
+
+// lineValue is how much a line that one side needs missing more marks to
+// complete, and the other side has not blocked, is worth. Each mark closer to
+// completing it makes a line ten times as valuable.
+func lineValue(missing int) int {
+	value := 1
+	for range max(0, 5-missing) {
+		value *= 10
+	}
+	return value
+}
+
+// candidateMoves lists the moves worth searching. On a 3x3 board that is
+// every empty square. On larger boards it is the empty squares next to a
+// played square, or the center of an empty board.
+func (g *Game) candidateMoves() [][2]int {
+	if !g.UsesCoordinates() {
+		return g.emptySquares()
+	}
+
+	var moves [][2]int
+	for _, square := range g.emptySquares() {
+		if g.hasNeighbour(square[0], square[1]) {
+			moves = append(moves, square)
+		}
+	}
+	if len(moves) == 0 {
+		center := g.Size() / 2
+		if g.board[center][center] == "" {
+			return [][2]int{{center, center}}
+		}
+		return g.emptySquares()
+	}
+	return moves
+}
+
+// hasNeighbour reports whether any square around the given one is played.
+func (g *Game) hasNeighbour(rowIndex, columnIndex int) bool {
+	for rowStep := -1; rowStep <= 1; rowStep++ {
+		for columnStep := -1; columnStep <= 1; columnStep++ {
+			row, column := rowIndex+rowStep, columnIndex+columnStep
+			if g.onBoard(row, column) && g.board[row][column] != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// orderedMoves lists the squares the computer considers in the order it
+// prefers them when they are equally good: the center, the corners in a
+// random order, then the rest row by row. On larger boards only squares
+// near the ones already played are considered, in a random order.
+func (g *Game) orderedMoves() [][2]int {
+	if g.UsesCoordinates() {
+		moves := g.candidateMoves()
+		g.rng.Shuffle(len(moves), func(i, j int) {
+			moves[i], moves[j] = moves[j], moves[i]
+		})
+		return moves
+	}
+
+	var moves [][2]int
+	if g.board[1][1] == "" {
+		moves = append(moves, [2]int{1, 1})
+	}
+
+	corners := [][2]int{{0, 0}, {0, 2}, {2, 0}, {2, 2}}
+	g.rng.Shuffle(len(corners), func(i, j int) {
+		corners[i], corners[j] = corners[j], corners[i]
+	})
+	for _, corner := range corners {
+		if g.board[corner[0]][corner[1]] == "" {
+			moves = append(moves, corner)
+		}
+	}
+
+	for _, square := range g.emptySquares() {
+		if (square[0]+square[1])%2 == 1 {
+			moves = append(moves, square)
+		}
+	}
+	return moves
+}
+
+// emptySquares lists the row and column of every empty square, row by row.
+func (g *Game) emptySquares() [][2]int {
+	return g.board.emptySquares()
+}
+
+// Analysis is the game-theoretic assessment of a position.
+type Analysis struct {
+	Turn      string   `json:"turn"`       // Turn is the mark to move, or empty once the game is over
+	Value     string   `json:"value"`      // Value is the result with best play: "X wins", "O wins", "draw" or "unclear"
+	BestMoves []string `json:"best_moves"` // BestMoves names every move that keeps the best result, row by row, with the mark to place in Wild
+}
+
+// Analyze searches the position for the result with best play from both
+// sides and the moves that achieve it. Winning moves are only best if they
+// win soonest, and losing moves if they lose latest.
+// A 3x3 board is searched to the end of the game. Larger boards are searched
+// as deeply as the computer opponent looks, so their value is "unclear"
+// unless a forced win is found within that many moves.
+func (g *Game) Analyze() Analysis {
+	if winner := g.GetWinner(); winner != "" {
+		return Analysis{Value: winner + " wins"}
+	}
+	if g.IsBoardFull() {
+		return Analysis{Value: "draw"}
+	}
+
+	analysis := Analysis{Turn: g.CurrentPlayer}
+	depth := 0
+	if g.UsesCoordinates() {
+		depth = largeBoardDepth(g.Size() * g.Size())
+	}
+	best := -2 * winScore
+	for _, move := range g.candidateMoves() {
+		for _, placed := range g.placements(g.CurrentPlayer) {
+			score := g.scoreMove(move, g.CurrentPlayer, placed, 1, depth, -2*winScore, 2*winScore)
+			if score > best {
+				best = score
+				analysis.BestMoves = nil
+			}
+			if score == best {
+				name := g.PositionName(move[0], move[1])
+				if g.ChoosesMark() {
+					name = placed + name
+				}
+				analysis.BestMoves = append(analysis.BestMoves, name)
+			}
+		}
+	}
+
+	switch {
+	case best > winScore/2:
+		analysis.Value = g.CurrentPlayer + " wins"
+	case best < -winScore/2:
+		analysis.Value = switchPlayer(g.CurrentPlayer) + " wins"
+	case g.UsesCoordinates():
+		analysis.Value = "unclear"
+	default:
+		analysis.Value = "draw"
+	}
+	return analysis
+}
@@ -0,0 +1,290 @@
+package tictactoe
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// exploreGames plays every possible sequence of moves for the opponent of the
+// computer, letting the computer answer each one, and calls done with the
+// finished game. It returns the number of games explored.
+func exploreGames(t *testing.T, game *Game, done func(*Game)) int {
+	t.Helper()
+	if game.GetWinner() != "" || game.IsBoardFull() {
+		done(game)
+		return 1
+	}
+
+	if game.IsComputerTurn() {
+		rowIndex, columnIndex := game.GetComputerMove()
+		if err := game.MakeMove(rowIndex, columnIndex); err != nil {
+			t.Fatalf("GetComputerMove() chose an illegal move (%d,%d) on %v: %v", rowIndex, columnIndex, game.board, err)
+		}
+		return exploreGames(t, game, done)
+	}
+
+	games := 0
+	for _, square := range game.emptySquares() {
+		next := *game
+		next.board = game.board.clone()
+		if err := next.MakeMove(square[0], square[1]); err != nil {
+			t.Fatalf("MakeMove(%d, %d) unexpected error: %v", square[0], square[1], err)
+		}
+		games += exploreGames(t, &next, done)
+	}
+	return games
+}
+
+func TestGetComputerMove_PerfectNeverLoses(t *testing.T) {
+	for _, humanMark := range []string{"X", "O"} {
+		for _, humanFirst := range []bool{true, false} {
+			name := fmt.Sprintf("human plays %s, human first %v", humanMark, humanFirst)
+			t.Run(name, func(t *testing.T) {
+				game := NewGame(ComputerGame, newTestRand())
+				game.Difficulty = Perfect
+				if err := game.ChooseSides(humanMark, humanFirst); err != nil {
+					t.Fatalf("ChooseSides() unexpected error: %v", err)
+				}
+
+				games := exploreGames(t, game, func(finished *Game) {
+					if winner := finished.GetWinner(); winner == humanMark {
+						t.Errorf("perfect computer lost to %s:%v", humanMark, finished)
+					}
+				})
+				if games == 0 {
+					t.Error("explored no games")
+				}
+			})
+		}
+	}
+}
+
+// walkPositions calls visit with every position reachable from game that is
+// not over, each once.
+func walkPositions(t *testing.T, game *Game, seen map[string]bool, visit func(*Game)) {
+	t.Helper()
+	if game.GetWinner() != "" || game.IsBoardFull() || seen[game.BoardString()] {
+		return
+	}
+	seen[game.BoardString()] = true
+	visit(game)
+
+	for _, square := range game.emptySquares() {
+		next := *game
+		next.board = game.board.clone()
+		if err := next.MakeMove(square[0], square[1]); err != nil {
+			t.Fatalf("MakeMove(%d, %d) unexpected error: %v", square[0], square[1], err)
+		}
+		walkPositions(t, &next, seen, visit)
+	}
+}
+
+func TestSuggestMove_PerfectPlaysABestMove(t *testing.T) {
+	for name, variant := range map[string]Variant{"standard": Standard, "misere": Misere} {
+		t.Run(name, func(t *testing.T) {
+			game := NewGame(LocalGame, newTestRand())
+			game.Variant = variant
+			positions := 0
+			walkPositions(t, game, map[string]bool{}, func(position *Game) {
+				positions++
+				best := position.Analyze().BestMoves
+				rowIndex, columnIndex := position.SuggestMove(Perfect)
+				if name := position.PositionName(rowIndex, columnIndex); !slices.Contains(best, name) {
+					t.Errorf("SuggestMove(Perfect) on %s = %s, want one of %v", position.BoardString(), name, best)
+				}
+			})
+			if positions == 0 {
+				t.Error("walked no positions")
+			}
+		})
+	}
+
+	// X wins with 9, which threatens both 5 and 8
+	game, err := ParseBoard("XOX...O..", 0)
+	if err != nil {
+		t.Fatalf("ParseBoard() unexpected error: %v", err)
+	}
+	game.rng = newTestRand()
+	if rowIndex, columnIndex := game.SuggestMove(Perfect); game.PositionName(rowIndex, columnIndex) != "9" {
+		t.Errorf("SuggestMove(Perfect) on XOX...O.. = %s, want the winning 9", game.PositionName(rowIndex, columnIndex))
+	}
+}
+
+func TestGetComputerMove_Difficulty(t *testing.T) {
+	// O must block at (0,2); only a search two moves deep sees the threat
+	board := Board{
+		{"X", "X", ""},
+		{"", "O", ""},
+		{"", "", ""},
+	}
+
+	tests := []struct {
+		difficulty      Difficulty
+		wantAlwaysBlock bool
+	}{
+		{difficulty: Perfect, wantAlwaysBlock: true},
+		{difficulty: Hard, wantAlwaysBlock: false},
+		{difficulty: Medium, wantAlwaysBlock: false},
+		{difficulty: Easy, wantAlwaysBlock: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.difficulty.String(), func(t *testing.T) {
+			blocked := 0
+			const tries = 200
+			for seed := int64(0); seed < tries; seed++ {
+				game := &Game{board: board, ComputerMark: "O", Difficulty: tt.difficulty, rng: rand.New(rand.NewSource(seed))}
+				rowIndex, columnIndex := game.GetComputerMove()
+				if board[rowIndex][columnIndex] != "" {
+					t.Fatalf("GetComputerMove() chose occupied square (%d,%d)", rowIndex, columnIndex)
+				}
+				if rowIndex == 0 && columnIndex == 2 {
+					blocked++
+				}
+			}
+			if tt.wantAlwaysBlock && blocked != tries {
+				t.Errorf("%s blocked %d of %d times, want every time", tt.difficulty, blocked, tries)
+			}
+			if !tt.wantAlwaysBlock && blocked == tries {
+				t.Errorf("%s blocked every time, want some mistakes", tt.difficulty)
+			}
+		})
+	}
+}
+
+func TestGetComputerMove_EasyMissesBlocks(t *testing.T) {
+	game := &Game{
+		board:        Board{{"X", "", "X"}, {"", "O", ""}, {"", "", ""}},
+		ComputerMark: "O",
+		Difficulty:   Easy,
+		rng:          newTestRand(),
+	}
+	saved := Levels[Easy]
+	Levels[Easy] = Level{Depth: saved.Depth}
+	defer func() { Levels[Easy] = saved }()
+
+	// Without random mistakes, easy only looks for its own wins, so it
+	// prefers a corner to the edge it needed to block
+	if rowIndex, columnIndex := game.GetComputerMove(); rowIndex == 0 && columnIndex == 1 {
+		t.Error("easy blocked the threat, want it to miss blocks")
+	}
+}
+
+func TestGetComputerMove_MediumTakesWinsAndBlocks(t *testing.T) {
+	saved := Levels[Medium]
+	Levels[Medium] = Level{Depth: saved.Depth}
+	defer func() { Levels[Medium] = saved }()
+
+	tests := []struct {
+		name  string
+		board Board
+		want  [2]int
+	}{
+		// Medium searches deep enough to see the threat it must block
+		{name: "block", board: Board{{"X", "X", ""}, {"", "O", ""}, {"", "", ""}}, want: [2]int{0, 2}},
+		// Winning straight away beats blocking
+		{name: "win", board: Board{{"X", "X", ""}, {"O", "O", ""}, {"X", "", ""}}, want: [2]int{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Without random mistakes, medium never misses either
+			for seed := int64(0); seed < 50; seed++ {
+				game := &Game{board: tt.board, ComputerMark: "O", Difficulty: Medium, rng: rand.New(rand.NewSource(seed))}
+				if rowIndex, columnIndex := game.GetComputerMove(); [2]int{rowIndex, columnIndex} != tt.want {
+					t.Fatalf("GetComputerMove() with seed %d = (%d,%d), want (%d,%d)", seed, rowIndex, columnIndex, tt.want[0], tt.want[1])
+				}
+			}
+		})
+	}
+}
+
+func TestParseDifficulty(t *testing.T) {
+	for _, name := range DifficultyNames() {
+		difficulty, err := ParseDifficulty(name)
+		if err != nil {
+			t.Errorf("ParseDifficulty(%q) unexpected error: %v", name, err)
+		}
+		if difficulty.String() != name {
+			t.Errorf("ParseDifficulty(%q) = %v, want %s", name, difficulty, name)
+		}
+	}
+	if _, err := ParseDifficulty("impossible"); err == nil {
+		t.Error("ParseDifficulty() accepted an unknown difficulty")
+	}
+}
+
+func TestGetComputerMove_LargeBoards(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      int
+		winLength int
+		oSquares  [][2]int
+		xSquares  [][2]int
+		want      [][2]int
+	}{
+		{
+			name: "opens in the center", size: 15, winLength: 5,
+			want: [][2]int{{7, 7}},
+		},
+		{
+			name: "completes five in a row", size: 15, winLength: 5,
+			oSquares: [][2]int{{7, 3}, {7, 4}, {7, 5}, {7, 6}},
+			xSquares: [][2]int{{6, 3}, {6, 4}, {8, 5}, {9, 9}},
+			want:     [][2]int{{7, 2}, {7, 7}},
+		},
+		{
+			name: "blocks a four", size: 15, winLength: 5,
+			oSquares: [][2]int{{4, 4}, {14, 14}, {0, 14}},
+			xSquares: [][2]int{{5, 5}, {6, 6}, {7, 7}, {8, 8}},
+			want:     [][2]int{{9, 9}},
+		},
+		{
+			name: "blocks three of four on 4x4", size: 4, winLength: 4,
+			oSquares: [][2]int{{0, 0}, {3, 3}},
+			xSquares: [][2]int{{1, 0}, {1, 1}, {1, 2}},
+			want:     [][2]int{{1, 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := NewSizedGame(ComputerGame, tt.size, tt.winLength, newTestRand())
+			if err != nil {
+				t.Fatalf("NewSizedGame() unexpected error: %v", err)
+			}
+			for _, square := range tt.oSquares {
+				game.board[square[0]][square[1]] = "O"
+			}
+			for _, square := range tt.xSquares {
+				game.board[square[0]][square[1]] = "X"
+			}
+			game.CurrentPlayer = "O"
+
+			rowIndex, columnIndex := game.GetComputerMove()
+			for _, want := range tt.want {
+				if rowIndex == want[0] && columnIndex == want[1] {
+					return
+				}
+			}
+			t.Errorf("GetComputerMove() = (%d,%d), want one of %v", rowIndex, columnIndex, tt.want)
+		})
+	}
+}
+
+func TestGetComputerMove_LargeBoardGameFinishes(t *testing.T) {
+	game, err := NewSizedGame(ComputerGame, 7, 4, newTestRand())
+	if err != nil {
+		t.Fatalf("NewSizedGame() unexpected error: %v", err)
+	}
+	// Let the computer play both sides until the game ends
+	for game.GetWinner() == "" && !game.IsBoardFull() {
+		game.ComputerMark = game.CurrentPlayer
+		rowIndex, columnIndex := game.GetComputerMove()
+		if err := game.MakeMove(rowIndex, columnIndex); err != nil {
+			t.Fatalf("GetComputerMove() chose an illegal move (%d,%d): %v", rowIndex, columnIndex, err)
+		}
+	}
+}
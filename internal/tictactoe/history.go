@@ -0,0 +1,72 @@
+package tictactoe
+
+import "errors"
+
+// CanUndo reports whether Undo can take a turn back.
+func (g *Game) CanUndo() bool {
+	return g.Takebacks && g.undoCount() > 0
+}
+
+// CanRedo reports whether Redo can play a turn taken back by Undo again.
+func (g *Game) CanRedo() bool {
+	return g.Takebacks && len(g.undone) > 0
+}
+
+// undoCount returns how many moves make up the last turn. In a local game
+// that is the last move. Against the computer it is the human's last move and
+// every move the computer made after it, so the human is back to move; it is
+// zero if the human has not moved yet.
+func (g *Game) undoCount() int {
+	if g.Mode != ComputerGame {
+		return min(len(g.history), 1)
+	}
+	for i := len(g.history) - 1; i >= 0; i-- {
+		if g.history[i].Player == g.HumanMark {
+			return len(g.history) - i
+		}
+	}
+	return 0
+}
+
+// Undo takes back the last turn: the last move in a local game, or the
+// human's last move and the computer's reply in a game against the computer.
+// The turn can be played again with Redo until another move is made.
+// Returns the moves taken back, in the order they were played, or an error
+// if takebacks are off or there is no turn to take back.
+func (g *Game) Undo() ([]Move, error) {
+	if !g.Takebacks {
+		return nil, errors.New("takebacks are turned off")
+	}
+	count := g.undoCount()
+	if count == 0 {
+		return nil, errors.New("no moves to undo")
+	}
+
+	turn := append([]Move(nil), g.history[len(g.history)-count:]...)
+	g.history = g.history[:len(g.history)-count]
+	for _, move := range turn {
+		g.board[move.RowIndex][move.ColumnIndex] = ""
+	}
+	g.CurrentPlayer = turn[0].Player
+	g.undone = append(g.undone, turn)
+	return turn, nil
+}
+
+// Redo plays the turn most recently taken back by Undo again.
+// Returns the moves played, or an error if takebacks are off or there is no
+// turn to redo.
+func (g *Game) Redo() ([]Move, error) {
+	if !g.Takebacks {
+		return nil, errors.New("takebacks are turned off")
+	}
+	if len(g.undone) == 0 {
+		return nil, errors.New("no moves to redo")
+	}
+
+	turn := g.undone[len(g.undone)-1]
+	g.undone = g.undone[:len(g.undone)-1]
+	for _, move := range turn {
+		g.play(move)
+	}
+	return turn, nil
+}
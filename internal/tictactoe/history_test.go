@@ -0,0 +1,152 @@
+package tictactoe
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUndoRedo_LocalGame(t *testing.T) {
+	game, err := ParseNotation("X5 O1 X9", 3, 0)
+	if err != nil {
+		t.Fatalf("ParseNotation() unexpected error: %v", err)
+	}
+
+	moves, err := game.Undo()
+	if err != nil {
+		t.Fatalf("Undo() unexpected error: %v", err)
+	}
+	if len(moves) != 1 || moves[0] != (Move{Player: "X", Mark: "X", RowIndex: 2, ColumnIndex: 2}) {
+		t.Errorf("Undo() = %v, want only X9", moves)
+	}
+	if game.Notation() != "X5 O1" || game.BoardString() != "O...X...." || game.CurrentPlayer != "X" {
+		t.Errorf("after Undo() = %q %q with %s to move, want X5 O1 with X to move", game.Notation(), game.BoardString(), game.CurrentPlayer)
+	}
+
+	if _, err := game.Redo(); err != nil {
+		t.Fatalf("Redo() unexpected error: %v", err)
+	}
+	if game.Notation() != "X5 O1 X9" || game.CurrentPlayer != "O" {
+		t.Errorf("after Redo() = %q with %s to move, want X5 O1 X9 with O to move", game.Notation(), game.CurrentPlayer)
+	}
+	if game.CanRedo() {
+		t.Error("CanRedo() = true with nothing left to redo")
+	}
+}
+
+func TestUndo_ComputerGameTakesBackBothMoves(t *testing.T) {
+	game := NewGame(ComputerGame, newTestRand())
+	if err := game.MakeMove(0, 0); err != nil {
+		t.Fatalf("MakeMove() unexpected error: %v", err)
+	}
+	rowIndex, columnIndex := game.GetComputerMove()
+	if err := game.MakeMove(rowIndex, columnIndex); err != nil {
+		t.Fatalf("MakeMove() unexpected error: %v", err)
+	}
+
+	moves, err := game.Undo()
+	if err != nil {
+		t.Fatalf("Undo() unexpected error: %v", err)
+	}
+	if len(moves) != 2 || moves[0].Mark != "X" || moves[1].Mark != "O" {
+		t.Errorf("Undo() = %v, want the human's X and the computer's O", moves)
+	}
+	if len(game.GetAvailablePositions()) != 9 || game.IsComputerTurn() {
+		t.Errorf("after Undo() board = %q with computer to move %v, want an empty board with the human to move", game.BoardString(), game.IsComputerTurn())
+	}
+	if game.CanUndo() {
+		t.Error("CanUndo() = true on an empty board")
+	}
+
+	moves, err = game.Redo()
+	if err != nil || len(moves) != 2 {
+		t.Fatalf("Redo() = %v, %v, want both moves played again", moves, err)
+	}
+	if game.IsComputerTurn() {
+		t.Error("after Redo() it is the computer's turn, want the human's")
+	}
+}
+
+func TestUndo_ComputerOpening(t *testing.T) {
+	game := NewGame(ComputerGame, newTestRand())
+	if err := game.ChooseSides("X", false); err != nil {
+		t.Fatalf("ChooseSides() unexpected error: %v", err)
+	}
+	if err := game.MakeMove(1, 1); err != nil {
+		t.Fatalf("MakeMove() unexpected error: %v", err)
+	}
+
+	// The human has not moved yet, so there is no turn of theirs to take back
+	if game.CanUndo() {
+		t.Error("CanUndo() = true before the human's first move")
+	}
+	if _, err := game.Undo(); err == nil {
+		t.Error("Undo() took back the computer's opening move")
+	}
+}
+
+func TestUndo_MoveClearsRedo(t *testing.T) {
+	game, err := ParseNotation("X5 O1", 3, 0)
+	if err != nil {
+		t.Fatalf("ParseNotation() unexpected error: %v", err)
+	}
+	if _, err := game.Undo(); err != nil {
+		t.Fatalf("Undo() unexpected error: %v", err)
+	}
+	if err := game.MakeMove(0, 2); err != nil {
+		t.Fatalf("MakeMove() unexpected error: %v", err)
+	}
+	if game.CanRedo() {
+		t.Error("CanRedo() = true after a new move")
+	}
+	if _, err := game.Redo(); err == nil {
+		t.Error("Redo() replayed a move after a new one was made")
+	}
+}
+
+func TestUndo_TakebacksOff(t *testing.T) {
+	game, err := ParseNotation("X5 O1", 3, 0)
+	if err != nil {
+		t.Fatalf("ParseNotation() unexpected error: %v", err)
+	}
+	game.Takebacks = false
+
+	if game.CanUndo() {
+		t.Error("CanUndo() = true with takebacks off")
+	}
+	if _, err := game.Undo(); err == nil {
+		t.Error("Undo() took a move back with takebacks off")
+	}
+	if options := takebackOptions(game); len(options) != 0 {
+		t.Errorf("takebackOptions() = %v with takebacks off, want none", options)
+	}
+}
+
+func TestGetPlayerMove_Takebacks(t *testing.T) {
+	game, err := ParseNotation("X5 O1", 3, 0)
+	if err != nil {
+		t.Fatalf("ParseNotation() unexpected error: %v", err)
+	}
+
+	// Seven free squares are listed first, followed by Undo
+	if _, _, err := GetPlayerMove(&mockPrompter{selectAnswers: []int{7}}, game); !errors.Is(err, ErrUndo) {
+		t.Errorf("GetPlayerMove() choosing Undo error = %v, want ErrUndo", err)
+	}
+	if _, err := game.Undo(); err != nil {
+		t.Fatalf("Undo() unexpected error: %v", err)
+	}
+	if _, _, err := GetPlayerMove(&mockPrompter{selectAnswers: []int{9}}, game); !errors.Is(err, ErrRedo) {
+		t.Errorf("GetPlayerMove() choosing Redo error = %v, want ErrRedo", err)
+	}
+
+	large, err := ParseNotation("Xc3", 5, 0)
+	if err != nil {
+		t.Fatalf("ParseNotation() unexpected error: %v", err)
+	}
+	if _, _, err := GetPlayerMove(&mockPrompter{inputAnswers: []string{"Undo"}}, large); !errors.Is(err, ErrUndo) {
+		t.Errorf("GetPlayerMove() typing undo error = %v, want ErrUndo", err)
+	}
+	large.Takebacks = false
+	if _, _, err := GetPlayerMove(&mockPrompter{inputAnswers: []string{"undo", "a1"}}, large); err != nil {
+		t.Errorf("GetPlayerMove() with takebacks off unexpected error: %v", err)
+	}
+}
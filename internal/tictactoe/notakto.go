@@ -0,0 +1,451 @@
+package tictactoe
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/chrisreddington/gh-game/internal/theme"
+)
+
+const (
+	// MaxNotaktoBoards is the most boards a game of Notakto can be played on,
+	// keeping the computer's search of the whole game quick
+	MaxNotaktoBoards = 3
+	// notaktoSquares is the number of squares on each Notakto board
+	notaktoSquares = 9
+)
+
+// notaktoSymmetries maps each square of a 3x3 board to where it goes under
+// each of the board's eight rotations and reflections.
+var notaktoSymmetries = [8][notaktoSquares]int{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8},
+	{6, 3, 0, 7, 4, 1, 8, 5, 2},
+	{8, 7, 6, 5, 4, 3, 2, 1, 0},
+	{2, 5, 8, 1, 4, 7, 0, 3, 6},
+	{2, 1, 0, 5, 4, 3, 8, 7, 6},
+	{6, 7, 8, 3, 4, 5, 0, 1, 2},
+	{0, 3, 6, 1, 4, 7, 2, 5, 8},
+	{8, 5, 2, 7, 4, 1, 6, 3, 0},
+}
+
+// notaktoLines are the squares of the three rows, three columns and two
+// diagonals of a 3x3 board, as bit masks.
+var notaktoLines = [8]uint16{
+	0b000000111, 0b000111000, 0b111000000,
+	0b001001001, 0b010010010, 0b100100100,
+	0b100010001, 0b001010100,
+}
+
+// NotaktoGame represents a game of Notakto: both players place X on one or
+// more 3x3 boards, and a board is dead once it has three in a row. Moves can
+// only be made on boards that are still alive, and whoever kills the last
+// board loses. The players are still told apart as X and O.
+//
+// Squares are numbered 1-9 on a single board. With several boards they are
+// named by the board's letter and the square's number, such as "b5" for the
+// center of the second board. Row and column indices run across the boards
+// side by side, so the second board's columns are 3-5.
+type NotaktoGame struct {
+	boards        []Board         // The boards, each a 3x3 board of X marks
+	history       []Move          // The moves made so far, in order
+	solved        map[string]bool // Whether the player to move wins each position searched, by its key
+	CurrentPlayer string          // CurrentPlayer indicates whose turn it is ("X" or "O")
+	Mode          GameMode        // Mode indicates if playing against computer or local player
+	ComputerMark  string          // ComputerMark stores which player (X/O) the computer is
+	HumanMark     string          // HumanMark stores which player (X/O) the human is against the computer
+	Difficulty    Difficulty      // Difficulty sets how well the computer plays
+	rng           *rand.Rand      // Source of randomness for the computer opponent
+}
+
+// NewNotaktoGame creates a game of Notakto on the given number of empty
+// boards. Player X moves first. Against the computer the human is X, unless
+// ChooseSides is called before the first move.
+// Returns an error if the number of boards is not between 1 and
+// MaxNotaktoBoards.
+func NewNotaktoGame(mode GameMode, boards int, rng *rand.Rand) (*NotaktoGame, error) {
+	if boards < 1 || boards > MaxNotaktoBoards {
+		return nil, fmt.Errorf("invalid number of boards %d: must be between 1 and %d", boards, MaxNotaktoBoards)
+	}
+
+	game := &NotaktoGame{
+		boards:        make([]Board, boards),
+		solved:        map[string]bool{},
+		CurrentPlayer: "X",
+		Mode:          mode,
+		rng:           rng,
+	}
+	for i := range game.boards {
+		game.boards[i] = NewBoard(MinSize)
+	}
+	if mode == ComputerGame {
+		game.HumanMark = "X"
+		game.ComputerMark = "O"
+	}
+	return game, nil
+}
+
+// ChooseSides sets which player the human is against the computer and which
+// side makes the first move.
+// Returns an error if:
+// - The game is not against the computer
+// - The mark is not "X" or "O"
+// - A move has already been made
+func (g *NotaktoGame) ChooseSides(humanMark string, humanFirst bool) error {
+	if g.Mode != ComputerGame {
+		return errors.New("sides can only be chosen against the computer")
+	}
+	if humanMark != "X" && humanMark != "O" {
+		return fmt.Errorf("invalid mark %q: must be X or O", humanMark)
+	}
+	if len(g.history) > 0 {
+		return errors.New("sides can only be chosen before the first move")
+	}
+
+	g.HumanMark = humanMark
+	g.ComputerMark = switchPlayer(humanMark)
+	g.CurrentPlayer = g.ComputerMark
+	if humanFirst {
+		g.CurrentPlayer = humanMark
+	}
+	return nil
+}
+
+// Turn returns the player about to move.
+func (g *NotaktoGame) Turn() string {
+	return g.CurrentPlayer
+}
+
+// Computer returns the computer's player, or an empty string in a local game.
+func (g *NotaktoGame) Computer() string {
+	return g.ComputerMark
+}
+
+// IsComputerTurn returns true if it's the computer's turn in a computer game.
+func (g *NotaktoGame) IsComputerTurn() bool {
+	return g.Mode == ComputerGame && g.CurrentPlayer == g.ComputerMark
+}
+
+// Boards returns the number of boards the game is played on.
+func (g *NotaktoGame) Boards() int {
+	return len(g.boards)
+}
+
+// dead reports whether a board has three in a row and can no longer be played.
+func (g *NotaktoGame) dead(board int) bool {
+	return g.boards[board].Winner(MinSize) != ""
+}
+
+// CheckMove returns the error MakeMove would give for a move to the
+// specified square, or nil if the move can be played.
+func (g *NotaktoGame) CheckMove(rowIndex, columnIndex int) error {
+	lastColumn := len(g.boards)*MinSize - 1
+	if rowIndex < 0 || rowIndex >= MinSize || columnIndex < 0 || columnIndex > lastColumn {
+		return fmt.Errorf("invalid position: must be in rows 0 to %d and columns 0 to %d", MinSize-1, lastColumn)
+	}
+	board := columnIndex / MinSize
+	if g.boards[board][rowIndex][columnIndex%MinSize] != "" {
+		return errors.New("position already taken")
+	}
+	if g.dead(board) {
+		return errors.New("that board is dead")
+	}
+	return nil
+}
+
+// MakeMove places an X on the specified square and passes the turn to the
+// other player.
+// Returns an error if the square is off the boards, already taken, or on a
+// dead board.
+func (g *NotaktoGame) MakeMove(rowIndex, columnIndex int) error {
+	if err := g.CheckMove(rowIndex, columnIndex); err != nil {
+		return err
+	}
+	g.boards[columnIndex/MinSize][rowIndex][columnIndex%MinSize] = "X"
+	g.history = append(g.history, Move{Player: g.CurrentPlayer, Mark: "X", RowIndex: rowIndex, ColumnIndex: columnIndex})
+	g.CurrentPlayer = switchPlayer(g.CurrentPlayer)
+	return nil
+}
+
+// Moves returns the moves made so far, in the order they were played.
+func (g *NotaktoGame) Moves() []Move {
+	return append([]Move(nil), g.history...)
+}
+
+// GetWinner returns the winning player once every board is dead. The player
+// who killed the last board lost, so the winner is the one whose turn it now
+// is. Returns an empty string while a board is still alive.
+func (g *NotaktoGame) GetWinner() string {
+	for board := range g.boards {
+		if !g.dead(board) {
+			return ""
+		}
+	}
+	return g.CurrentPlayer
+}
+
+// IsBoardFull reports whether no moves are left. That only happens once
+// every board is dead, since a full board always has three in a row.
+func (g *NotaktoGame) IsBoardFull() bool {
+	return len(g.legalMoves()) == 0
+}
+
+// legalMoves lists the squares that can be played, board by board.
+func (g *NotaktoGame) legalMoves() [][2]int {
+	var moves [][2]int
+	for board := range g.boards {
+		if g.dead(board) {
+			continue
+		}
+		for _, square := range g.boards[board].emptySquares() {
+			moves = append(moves, [2]int{square[0], board*MinSize + square[1]})
+		}
+	}
+	return moves
+}
+
+// emptySquares lists the row and column of every empty square, row by row.
+func (b Board) emptySquares() [][2]int {
+	var squares [][2]int
+	for rowIndex, row := range b {
+		for columnIndex, mark := range row {
+			if mark == "" {
+				squares = append(squares, [2]int{rowIndex, columnIndex})
+			}
+		}
+	}
+	return squares
+}
+
+// UsesCoordinates reports whether squares are named by board letter and
+// square number, which happens when there is more than one board.
+func (g *NotaktoGame) UsesCoordinates() bool {
+	return len(g.boards) > 1
+}
+
+// PositionName returns the name of a square: its number on a single board,
+// or its board's letter and its number, such as "b5", on several.
+func (g *NotaktoGame) PositionName(rowIndex, columnIndex int) string {
+	number := strconv.Itoa(rowIndex*MinSize + columnIndex%MinSize + 1)
+	if !g.UsesCoordinates() {
+		return number
+	}
+	return columnName(columnIndex/MinSize) + number
+}
+
+// ParsePosition converts a square's name, as returned by PositionName, to
+// zero-based row and column indices. Board letters are not case-sensitive.
+func (g *NotaktoGame) ParsePosition(name string) (rowIndex, columnIndex int, err error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	board := 0
+	if g.UsesCoordinates() {
+		if len(name) != 2 || name[0] < 'a' || int(name[0]-'a') >= len(g.boards) {
+			return -1, -1, fmt.Errorf("invalid square %q: use a board from a to %s and a square from 1 to 9, such as b5", name, columnName(len(g.boards)-1))
+		}
+		board = int(name[0] - 'a')
+		name = name[1:]
+	}
+	position, err := strconv.Atoi(name)
+	rowIndex, columnIndex = positionToRowCol(position)
+	if err != nil || rowIndex < 0 {
+		return -1, -1, fmt.Errorf("invalid position %q: must be a number from 1 to 9", name)
+	}
+	return rowIndex, board*MinSize + columnIndex, nil
+}
+
+// GetAvailablePositions returns the names of the squares that can be played,
+// board by board.
+func (g *NotaktoGame) GetAvailablePositions() []string {
+	var positions []string
+	for _, move := range g.legalMoves() {
+		positions = append(positions, g.PositionName(move[0], move[1]))
+	}
+	return positions
+}
+
+// GetComputerMove chooses the computer's next move by searching the whole
+// game. Notakto is small enough to solve outright once positions that are
+// rotations or reflections of each other are treated as one, so at every
+// difficulty the computer plays a winning move whenever there is one;
+// easier difficulties only differ in how often they play at random instead.
+// Returns row and column indices for the chosen move, or (-1, -1) if no
+// moves are left.
+func (g *NotaktoGame) GetComputerMove() (rowIndex, columnIndex int) {
+	return g.SuggestMove(g.Difficulty)
+}
+
+// SuggestMove chooses a move for the player about to move the way the
+// computer would at the given difficulty, whoever that player is.
+// Returns (-1, -1) if no moves are left.
+func (g *NotaktoGame) SuggestMove(difficulty Difficulty) (rowIndex, columnIndex int) {
+	moves := g.legalMoves()
+	if len(moves) == 0 {
+		return -1, -1
+	}
+	g.rng.Shuffle(len(moves), func(i, j int) {
+		moves[i], moves[j] = moves[j], moves[i]
+	})
+
+	level := Levels[difficulty]
+	if level.MistakeRate > 0 && g.rng.Float64() < level.MistakeRate {
+		return moves[0][0], moves[0][1]
+	}
+
+	// Play a winning move, or failing that one that does not lose straight
+	// away, so the opponent still has to find the win
+	masks := g.masks()
+	best := moves[0]
+	for _, move := range moves {
+		board := move[1] / MinSize
+		bit := uint16(1) << (move[0]*MinSize + move[1]%MinSize)
+		after := slices.Clone(masks)
+		after[board] |= bit
+		if !g.wins(after) {
+			return move[0], move[1]
+		}
+		if slices.ContainsFunc(after, notaktoAlive) {
+			best = move
+		}
+	}
+	return best[0], best[1]
+}
+
+// masks returns each board as a bit mask of its played squares, with square
+// 1 as the lowest bit.
+func (g *NotaktoGame) masks() []uint16 {
+	masks := make([]uint16, len(g.boards))
+	for board := range g.boards {
+		for rowIndex, row := range g.boards[board] {
+			for columnIndex, mark := range row {
+				if mark != "" {
+					masks[board] |= 1 << (rowIndex*MinSize + columnIndex)
+				}
+			}
+		}
+	}
+	return masks
+}
+
+// wins reports whether the player about to move on the boards with the
+// given masks can force a win. With no board left alive, the opponent killed
+// the last one and so has lost.
+func (g *NotaktoGame) wins(masks []uint16) bool {
+	key := notaktoKey(masks)
+	if won, ok := g.solved[key]; ok {
+		return won
+	}
+
+	won := !slices.ContainsFunc(masks, notaktoAlive)
+	for board, mask := range masks {
+		if !notaktoAlive(mask) {
+			continue
+		}
+		for square := range notaktoSquares {
+			bit := uint16(1) << square
+			if mask&bit != 0 {
+				continue
+			}
+			after := slices.Clone(masks)
+			after[board] |= bit
+			if !g.wins(after) {
+				won = true
+				break
+			}
+		}
+		if won {
+			break
+		}
+	}
+	g.solved[key] = won
+	return won
+}
+
+// notaktoAlive reports whether a board mask has no three in a row.
+func notaktoAlive(mask uint16) bool {
+	for _, line := range notaktoLines {
+		if mask&line == line {
+			return false
+		}
+	}
+	return true
+}
+
+// notaktoKey identifies a position for the search. Dead boards are left out,
+// since they can no longer be played, and the boards that are still alive are
+// each replaced by the smallest of their rotations and reflections and then
+// sorted, since the order of the boards does not matter either.
+func notaktoKey(masks []uint16) string {
+	var canonical []int
+	for _, mask := range masks {
+		if !notaktoAlive(mask) {
+			continue
+		}
+		smallest := mask
+		for _, symmetry := range notaktoSymmetries {
+			var mapped uint16
+			for square, to := range symmetry {
+				if mask&(1<<square) != 0 {
+					mapped |= 1 << to
+				}
+			}
+			smallest = min(smallest, mapped)
+		}
+		canonical = append(canonical, int(smallest))
+	}
+	slices.Sort(canonical)
+	return fmt.Sprint(canonical)
+}
+
+// String returns the boards side by side, labelled with their letters when
+// there are several. Empty squares on boards that are still alive show their
+// numbers; dead boards are dimmed.
+func (g *NotaktoGame) String() string {
+	var sb strings.Builder
+	sb.WriteString("\n")
+	if g.UsesCoordinates() {
+		labels := make([]string, len(g.boards))
+		for board := range g.boards {
+			label := strings.ToUpper(columnName(board))
+			if g.dead(board) {
+				labels[board] = theme.Muted.Render(fmt.Sprintf(" %-10s", label+" (dead)"))
+			} else {
+				labels[board] = fmt.Sprintf(" %-10s", label)
+			}
+		}
+		sb.WriteString(strings.Join(labels, "   ") + "\n")
+	}
+
+	for rowIndex := range MinSize {
+		for board := range g.boards {
+			if board > 0 {
+				sb.WriteString("   ")
+			}
+			cells := make([]string, MinSize)
+			for columnIndex, mark := range g.boards[board][rowIndex] {
+				switch {
+				case mark != "" && g.dead(board):
+					cells[columnIndex] = theme.Muted.Render(mark)
+				case mark != "":
+					cells[columnIndex] = RenderMark(mark)
+				case g.dead(board):
+					cells[columnIndex] = theme.Muted.Render(".")
+				default:
+					cells[columnIndex] = strconv.Itoa(rowIndex*MinSize + columnIndex + 1)
+				}
+			}
+			sb.WriteString(" " + strings.Join(cells, " | ") + " ")
+		}
+		sb.WriteString("\n")
+		if rowIndex < MinSize-1 {
+			separators := make([]string, len(g.boards))
+			for board := range separators {
+				separators[board] = "---+---+---"
+			}
+			sb.WriteString(strings.Join(separators, "   ") + "\n")
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,139 @@
+package tictactoe
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// playNotakto makes each move, named by its square, failing the test if any
+// is rejected.
+func playNotakto(t *testing.T, game *NotaktoGame, squares ...string) {
+	t.Helper()
+	for _, square := range squares {
+		rowIndex, columnIndex, err := game.ParsePosition(square)
+		if err != nil {
+			t.Fatalf("ParsePosition(%q) unexpected error: %v", square, err)
+		}
+		if err := game.MakeMove(rowIndex, columnIndex); err != nil {
+			t.Fatalf("MakeMove(%s) unexpected error: %v", square, err)
+		}
+	}
+}
+
+func TestNewNotaktoGame(t *testing.T) {
+	for _, boards := range []int{0, MaxNotaktoBoards + 1} {
+		if _, err := NewNotaktoGame(LocalGame, boards, newTestRand()); err == nil {
+			t.Errorf("NewNotaktoGame(%d boards) did not return an error", boards)
+		}
+	}
+
+	game, err := NewNotaktoGame(ComputerGame, 2, newTestRand())
+	if err != nil {
+		t.Fatalf("NewNotaktoGame() unexpected error: %v", err)
+	}
+	positions := game.GetAvailablePositions()
+	if len(positions) != 18 || positions[0] != "a1" || positions[17] != "b9" {
+		t.Errorf("GetAvailablePositions() = %v, want a1-a9 and b1-b9", positions)
+	}
+	if game.HumanMark != "X" || game.ComputerMark != "O" {
+		t.Errorf("NewNotaktoGame() human %s, computer %s, want X and O", game.HumanMark, game.ComputerMark)
+	}
+}
+
+func TestNotaktoGame_Positions(t *testing.T) {
+	single, _ := NewNotaktoGame(LocalGame, 1, newTestRand())
+	if single.UsesCoordinates() || single.PositionName(1, 2) != "6" {
+		t.Errorf("PositionName(1,2) on one board = %q, want 6", single.PositionName(1, 2))
+	}
+
+	game, _ := NewNotaktoGame(LocalGame, 3, newTestRand())
+	rowIndex, columnIndex, err := game.ParsePosition("B5")
+	if err != nil || rowIndex != 1 || columnIndex != 4 {
+		t.Errorf("ParsePosition(B5) = (%d,%d), %v, want (1,4)", rowIndex, columnIndex, err)
+	}
+	if name := game.PositionName(2, 8); name != "c9" {
+		t.Errorf("PositionName(2,8) = %q, want c9", name)
+	}
+	for _, name := range []string{"d1", "a0", "a10", "5"} {
+		if _, _, err := game.ParsePosition(name); err == nil {
+			t.Errorf("ParsePosition(%q) did not return an error", name)
+		}
+	}
+}
+
+func TestNotaktoGame_DeadBoards(t *testing.T) {
+	game, _ := NewNotaktoGame(LocalGame, 2, newTestRand())
+
+	// X, O and X fill the top row of board a, killing it
+	playNotakto(t, game, "a1", "a2", "a3")
+	if winner := game.GetWinner(); winner != "" {
+		t.Errorf("GetWinner() = %q with board b alive, want no winner", winner)
+	}
+	rowIndex, columnIndex, _ := game.ParsePosition("a5")
+	if err := game.MakeMove(rowIndex, columnIndex); err == nil || !strings.Contains(err.Error(), "dead") {
+		t.Errorf("MakeMove(a5) error = %v, want the board reported dead", err)
+	}
+	if positions := game.GetAvailablePositions(); len(positions) != 9 || positions[0] != "b1" {
+		t.Errorf("GetAvailablePositions() = %v, want only board b", positions)
+	}
+
+	// O, X and O fill the middle column of board b; O kills the last board
+	// and loses
+	playNotakto(t, game, "b2", "b5", "b8")
+	if winner := game.GetWinner(); winner != "X" {
+		t.Errorf("GetWinner() = %q after O killed the last board, want X", winner)
+	}
+	if !game.IsBoardFull() {
+		t.Error("IsBoardFull() = false with every board dead")
+	}
+	for _, move := range game.Moves() {
+		if move.Mark != "X" {
+			t.Errorf("Moves() includes %v, want every move to place X", move)
+		}
+	}
+}
+
+func TestNotaktoGame_Solve(t *testing.T) {
+	// The first player wins on one or three boards, and the second on two
+	for boards, want := range map[int]bool{1: true, 2: false, 3: true} {
+		game, _ := NewNotaktoGame(LocalGame, boards, newTestRand())
+		if got := game.wins(game.masks()); got != want {
+			t.Errorf("wins() on %d empty boards = %v, want %v", boards, got, want)
+		}
+	}
+}
+
+func TestNotaktoGame_PerfectPlayWins(t *testing.T) {
+	// The first player wins a single board with perfect play, whatever the
+	// opponent does
+	for seed := range 5 {
+		game, _ := NewNotaktoGame(LocalGame, 1, rand.New(rand.NewSource(int64(seed))))
+		for game.GetWinner() == "" {
+			difficulty := Perfect
+			if game.CurrentPlayer == "O" {
+				difficulty = Easy
+			}
+			rowIndex, columnIndex := game.SuggestMove(difficulty)
+			if err := game.MakeMove(rowIndex, columnIndex); err != nil {
+				t.Fatalf("seed %d: MakeMove() unexpected error: %v", seed, err)
+			}
+		}
+		if winner := game.GetWinner(); winner != "X" {
+			t.Errorf("seed %d: GetWinner() = %q, want the perfect first player to win", seed, winner)
+		}
+	}
+}
+
+func TestNotaktoGame_String(t *testing.T) {
+	game, _ := NewNotaktoGame(LocalGame, 2, newTestRand())
+	playNotakto(t, game, "a1", "a2", "a3", "b5")
+
+	board := game.String()
+	if !strings.Contains(board, "A (dead)") || strings.Contains(board, "B (dead)") {
+		t.Errorf("String() = %q, want board A shown dead and board B alive", board)
+	}
+	if !strings.Contains(board, " 4 | X | 6 ") {
+		t.Errorf("String() = %q, want board B's middle row to number its empty squares", board)
+	}
+}
@@ -0,0 +1,177 @@
+package tictactoe
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// emptySquare is the character standing for an empty square in a board string
+const emptySquare = '.'
+
+// Move is a mark placed on a square of the board.
+type Move struct {
+	Player      string // Player is who made the move ("X" or "O"), which differs from Mark only in Wild
+	Mark        string // Mark is the mark placed ("X" or "O")
+	RowIndex    int    // RowIndex is the zero-based row of the square
+	ColumnIndex int    // ColumnIndex is the zero-based column of the square
+}
+
+// DefaultWinLength returns how many marks in a row win on a board with size
+// rows and columns unless a game says otherwise: the whole row, up to five in
+// a row on big boards.
+func DefaultWinLength(size int) int {
+	return min(size, 5)
+}
+
+// Moves returns the moves made so far, in the order they were played.
+func (g *Game) Moves() []Move {
+	return append([]Move(nil), g.history...)
+}
+
+// Notation writes the moves made so far as the mark and square of each move
+// separated by spaces, such as "X5 O1 X9" on a 3x3 board or "Xh8 Oh9" on a
+// larger one. Moves made before the game was loaded from a board string are
+// not included.
+func (g *Game) Notation() string {
+	moves := make([]string, len(g.history))
+	for i, move := range g.history {
+		moves[i] = move.Mark + g.PositionName(move.RowIndex, move.ColumnIndex)
+	}
+	return strings.Join(moves, " ")
+}
+
+// BoardString writes the board row by row as one character per square: X, O,
+// or "." for an empty square, such as "X.O......".
+func (g *Game) BoardString() string {
+	var sb strings.Builder
+	for _, row := range g.board {
+		for _, mark := range row {
+			if mark == "" {
+				sb.WriteRune(emptySquare)
+			} else {
+				sb.WriteString(mark)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// ParseNotation replays moves written by Notation on an empty local game
+// with size rows and columns, won by winLength in a row, or
+// DefaultWinLength(size) if winLength is zero. The first move decides which
+// mark starts, and the marks must then alternate.
+// Returns an error if the board size or win length is invalid, or a move is
+// badly written, out of turn or cannot be played.
+func ParseNotation(notation string, size, winLength int) (*Game, error) {
+	if winLength == 0 {
+		winLength = DefaultWinLength(size)
+	}
+	game, err := NewSizedGame(LocalGame, size, winLength, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, token := range strings.Fields(notation) {
+		mark := strings.ToUpper(token[:1])
+		if mark != "X" && mark != "O" {
+			return nil, fmt.Errorf("move %d %q: must start with X or O", i+1, token)
+		}
+		if i == 0 {
+			game.CurrentPlayer = mark
+		}
+		if mark != game.CurrentPlayer {
+			return nil, fmt.Errorf("move %d %q: it is %s's turn", i+1, token, game.CurrentPlayer)
+		}
+		if game.GetWinner() != "" {
+			return nil, fmt.Errorf("move %d %q: the game is already over", i+1, token)
+		}
+		rowIndex, columnIndex, err := game.ParsePosition(token[1:])
+		if err != nil {
+			return nil, fmt.Errorf("move %d %q: %w", i+1, token, err)
+		}
+		if err := game.MakeMove(rowIndex, columnIndex); err != nil {
+			return nil, fmt.Errorf("move %d %q: %w", i+1, token, err)
+		}
+	}
+	return game, nil
+}
+
+// ParseBoard loads a local game from a board string written by BoardString.
+// The board size follows from the length of the string, and the game is won
+// by winLength in a row, or DefaultWinLength(size) if winLength is zero.
+// Whose turn it is follows from the number of marks: X moves when both have
+// played equally often, otherwise the side with fewer marks.
+// Returns an error if the string is not a square board of X, O and "."
+// characters, or one side has played more than once more than the other.
+func ParseBoard(board string, winLength int) (*Game, error) {
+	size := int(math.Sqrt(float64(len(board))))
+	if size*size != len(board) {
+		return nil, fmt.Errorf("invalid board %q: must have a square number of squares, such as 9 for 3x3", board)
+	}
+	if winLength == 0 {
+		winLength = DefaultWinLength(size)
+	}
+	game, err := NewSizedGame(LocalGame, size, winLength, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int{}
+	for i, square := range strings.ToUpper(board) {
+		switch square {
+		case emptySquare:
+		case 'X', 'O':
+			game.board[i/size][i%size] = string(square)
+			counts[string(square)]++
+		default:
+			return nil, fmt.Errorf("invalid board %q: squares must be X, O or %c", board, emptySquare)
+		}
+	}
+
+	switch counts["X"] - counts["O"] {
+	case 0, -1:
+		game.CurrentPlayer = "X"
+	case 1:
+		game.CurrentPlayer = "O"
+	default:
+		return nil, fmt.Errorf("invalid board %q: X has %d marks and O has %d, so they cannot have taken turns", board, counts["X"], counts["O"])
+	}
+	return game, nil
+}
+
+// ParseGame loads a local game from either a board string, such as
+// "X.O......", or moves in notation, such as "X5 O1". Moves are played on a
+// board with size rows and columns, or 3x3 if size is zero; a board string
+// sets its own size, which must match size if that is not zero.
+func ParseGame(position string, size, winLength int) (*Game, error) {
+	position = strings.TrimSpace(position)
+	if isBoardString(position) {
+		game, err := ParseBoard(position, winLength)
+		if err != nil {
+			return nil, err
+		}
+		if size != 0 && game.Size() != size {
+			return nil, fmt.Errorf("board %q is %dx%d, not %dx%d", position, game.Size(), game.Size(), size, size)
+		}
+		return game, nil
+	}
+	if size == 0 {
+		size = MinSize
+	}
+	return ParseNotation(position, size, winLength)
+}
+
+// isBoardString reports whether position is made of board string characters
+// only, as opposed to moves in notation.
+func isBoardString(position string) bool {
+	if len(position) < MinSize*MinSize {
+		return false
+	}
+	for _, square := range strings.ToUpper(position) {
+		if square != 'X' && square != 'O' && square != emptySquare {
+			return false
+		}
+	}
+	return true
+}
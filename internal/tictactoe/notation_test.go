@@ -0,0 +1,171 @@
+package tictactoe
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNotation(t *testing.T) {
+	game := NewGame(LocalGame, newTestRand())
+	for _, square := range [][2]int{{1, 1}, {0, 0}, {2, 2}} {
+		if err := game.MakeMove(square[0], square[1]); err != nil {
+			t.Fatalf("MakeMove() unexpected error: %v", err)
+		}
+	}
+
+	if got, want := game.Notation(), "X5 O1 X9"; got != want {
+		t.Errorf("Notation() = %q, want %q", got, want)
+	}
+	if got, want := game.BoardString(), "O...X...X"; got != want {
+		t.Errorf("BoardString() = %q, want %q", got, want)
+	}
+	if got := len(game.Moves()); got != 3 {
+		t.Errorf("Moves() has %d moves, want 3", got)
+	}
+}
+
+func TestParseNotation(t *testing.T) {
+	tests := []struct {
+		name      string
+		notation  string
+		size      int
+		wantBoard string
+		wantTurn  string
+		wantErr   bool
+	}{
+		{name: "no moves", notation: "", size: 3, wantBoard: ".........", wantTurn: "X"},
+		{name: "round trip", notation: "X5 O1 X9", size: 3, wantBoard: "O...X...X", wantTurn: "O"},
+		{name: "O moves first", notation: "o5 x1", size: 3, wantBoard: "X...O....", wantTurn: "O"},
+		{name: "coordinates", notation: "Xb2 Oa1", size: 4, wantBoard: "O....X..........", wantTurn: "X"},
+		{name: "out of turn", notation: "X5 X1", size: 3, wantErr: true},
+		{name: "taken square", notation: "X5 O5", size: 3, wantErr: true},
+		{name: "unknown mark", notation: "Z5", size: 3, wantErr: true},
+		{name: "bad square", notation: "X10", size: 3, wantErr: true},
+		{name: "move after the end", notation: "X1 O4 X2 O5 X3 O6", size: 3, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := ParseNotation(tt.notation, tt.size, 0)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseNotation(%q) = %q, want an error", tt.notation, game.BoardString())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseNotation(%q) unexpected error: %v", tt.notation, err)
+			}
+			if got := game.BoardString(); got != tt.wantBoard {
+				t.Errorf("ParseNotation(%q) board = %q, want %q", tt.notation, got, tt.wantBoard)
+			}
+			if game.CurrentPlayer != tt.wantTurn {
+				t.Errorf("ParseNotation(%q) turn = %s, want %s", tt.notation, game.CurrentPlayer, tt.wantTurn)
+			}
+		})
+	}
+}
+
+func TestParseBoard(t *testing.T) {
+	tests := []struct {
+		board    string
+		wantTurn string
+		wantSize int
+		wantErr  bool
+	}{
+		{board: "X.O......", wantTurn: "X", wantSize: 3},
+		{board: "x...o...x", wantTurn: "O", wantSize: 3},
+		{board: "O........", wantTurn: "X", wantSize: 3},
+		{board: "X...............", wantTurn: "O", wantSize: 4},
+		{board: "XX.......", wantErr: true},
+		{board: "X.O.....", wantErr: true},
+		{board: "X.O...?..", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.board, func(t *testing.T) {
+			game, err := ParseBoard(tt.board, 0)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseBoard(%q) did not return an error", tt.board)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseBoard(%q) unexpected error: %v", tt.board, err)
+			}
+			if game.CurrentPlayer != tt.wantTurn || game.Size() != tt.wantSize {
+				t.Errorf("ParseBoard(%q) = %dx%d with %s to move, want %dx%d with %s", tt.board, game.Size(), game.Size(), game.CurrentPlayer, tt.wantSize, tt.wantSize, tt.wantTurn)
+			}
+		})
+	}
+}
+
+func TestParseGame(t *testing.T) {
+	if game, err := ParseGame("X.O......", 0, 0); err != nil || game.BoardString() != "X.O......" {
+		t.Errorf("ParseGame() did not load a board string: %v", err)
+	}
+	if game, err := ParseGame("X5", 0, 0); err != nil || game.BoardString() != "....X...." {
+		t.Errorf("ParseGame() did not load moves: %v", err)
+	}
+	if _, err := ParseGame("X.O......", 4, 0); err == nil {
+		t.Error("ParseGame() accepted a 3x3 board string for a 4x4 game")
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name          string
+		position      string
+		wantTurn      string
+		wantValue     string
+		wantBestMoves []string
+	}{
+		{name: "empty board is a draw", position: ".........", wantTurn: "X", wantValue: "draw", wantBestMoves: []string{"1", "2", "3", "4", "5", "6", "7", "8", "9"}},
+		{name: "edge reply to the center loses", position: "X5 O2", wantTurn: "X", wantValue: "X wins"},
+		{name: "corner reply to the center draws", position: "X5 O1", wantTurn: "X", wantValue: "draw"},
+		{name: "win at once", position: "XX.OO....", wantTurn: "X", wantValue: "X wins", wantBestMoves: []string{"3"}},
+		{name: "only block", position: "XX..O....", wantTurn: "O", wantValue: "draw", wantBestMoves: []string{"3"}},
+		{name: "finished game", position: "XXXOO....", wantValue: "X wins"},
+		{name: "full board", position: "XOXXOOOXX", wantValue: "draw"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := ParseGame(tt.position, 0, 0)
+			if err != nil {
+				t.Fatalf("ParseGame(%q) unexpected error: %v", tt.position, err)
+			}
+			analysis := game.Analyze()
+			if analysis.Turn != tt.wantTurn || analysis.Value != tt.wantValue {
+				t.Errorf("Analyze() = %s to move, %s, want %s to move, %s", analysis.Turn, analysis.Value, tt.wantTurn, tt.wantValue)
+			}
+			if tt.wantBestMoves != nil && !slices.Equal(analysis.BestMoves, tt.wantBestMoves) {
+				t.Errorf("Analyze() best moves = %v, want %v", analysis.BestMoves, tt.wantBestMoves)
+			}
+			if analysis.Turn == "" && len(analysis.BestMoves) > 0 {
+				t.Errorf("Analyze() suggested moves in a finished game: %v", analysis.BestMoves)
+			}
+		})
+	}
+}
+
+func TestAnalyze_LargeBoards(t *testing.T) {
+	// X has an open three on a 7x7 board, four in a row to win, so it can
+	// make a four that O cannot block at both ends
+	game, err := ParseGame("Xc4 Oa1 Xd4 Oa7 Xe4 Og1", 7, 4)
+	if err != nil {
+		t.Fatalf("ParseGame() unexpected error: %v", err)
+	}
+	if analysis := game.Analyze(); analysis.Value != "X wins" {
+		t.Errorf("Analyze() value = %s, want X wins", analysis.Value)
+	}
+
+	quiet, err := ParseGame("Xd4", 7, 4)
+	if err != nil {
+		t.Fatalf("ParseGame() unexpected error: %v", err)
+	}
+	if analysis := quiet.Analyze(); analysis.Value != "unclear" {
+		t.Errorf("Analyze() value of a quiet large board = %s, want unclear", analysis.Value)
+	}
+}
@@ -0,0 +1,645 @@
+package tictactoe
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/chrisreddington/gh-game/internal/theme"
+)
+
+const (
+	// quantumSquares is the number of squares on the quantum board
+	quantumSquares = 9
+	// quantumDepth is how many turns ahead the computer searches in quantum
+	// tic-tac-toe when its difficulty does not limit the search further
+	quantumDepth = 2
+	// quantumCellWidth is how many characters wide each square is drawn
+	quantumCellWidth = 9
+)
+
+// quantumLines are the squares of the three rows, three columns and two
+// diagonals of the board.
+var quantumLines = [8][3]int{
+	{0, 1, 2}, {3, 4, 5}, {6, 7, 8},
+	{0, 3, 6}, {1, 4, 7}, {2, 5, 8},
+	{0, 4, 8}, {2, 4, 6},
+}
+
+// quantumMark is a mark placed in quantum tic-tac-toe. It is spooky, in two
+// squares at once, until it collapses into one of them.
+type quantumMark struct {
+	Player string // Player is the mark's owner ("X" or "O")
+	Number int    // Number is the move the mark was placed on, counting from 1
+	Cells  [2]int // Cells are the two squares the mark is in, from 0 to 8
+	Square int    // Square is the square the mark collapsed into, or -1 while it is spooky
+}
+
+// name returns the mark's owner and move number, such as "X3".
+func (m quantumMark) name() string {
+	return m.Player + strconv.Itoa(m.Number)
+}
+
+// QuantumGame represents a game of quantum tic-tac-toe. Each move places a
+// spooky mark in two squares at once, and the marks sharing a square are
+// entangled. When a mark closes a cycle of entangled marks, the player who
+// did not close it chooses which of its two squares it collapses into; every
+// mark entangled with it then collapses too, each into the square the others
+// leave it. Collapsed marks are classical, and three classical marks in a row
+// win. When the last square left empty is the only one, it takes a classical
+// mark directly.
+//
+// A move is made up of the calls to MakeMove for the squares it involves:
+// one call for each half of a spooky mark, and one for the square chosen in a
+// collapse, which is made by the player Turn says must choose.
+//
+// A collapse can complete lines for both players at once. Then the player
+// whose line was finished earlier, judged by the highest move number in it,
+// scores 1 and the other ½.
+type QuantumGame struct {
+	marks         []quantumMark       // Every mark placed, in move order
+	classical     [quantumSquares]int // The index in marks of the mark collapsed into each square, or -1
+	pending       int                 // The square of the first half of the mark being placed, or -1
+	collapse      int                 // The index in marks of the mark whose collapse must be chosen, or -1
+	plan          [2]int              // The two squares the computer chose for its mark, or {-1, -1}
+	CurrentPlayer string              // CurrentPlayer indicates whose turn it is ("X" or "O")
+	Mode          GameMode            // Mode indicates if playing against computer or local player
+	ComputerMark  string              // ComputerMark stores which mark (X/O) the computer is using
+	HumanMark     string              // HumanMark stores which mark (X/O) the human plays against the computer
+	Difficulty    Difficulty          // Difficulty sets how well the computer plays
+	rng           *rand.Rand          // Source of randomness for the computer opponent
+}
+
+// NewQuantumGame creates a game of quantum tic-tac-toe on an empty board. X
+// plays first. Against the computer the human plays X, unless ChooseSides is
+// called before the first move.
+func NewQuantumGame(mode GameMode, rng *rand.Rand) *QuantumGame {
+	game := &QuantumGame{
+		pending:       -1,
+		collapse:      -1,
+		plan:          [2]int{-1, -1},
+		CurrentPlayer: "X",
+		Mode:          mode,
+		rng:           rng,
+	}
+	for square := range game.classical {
+		game.classical[square] = -1
+	}
+	if mode == ComputerGame {
+		game.HumanMark = "X"
+		game.ComputerMark = "O"
+	}
+	return game
+}
+
+// ChooseSides sets the mark the human plays against the computer and which
+// side makes the first move, whichever mark that is.
+// Returns an error if:
+// - The game is not against the computer
+// - The mark is not "X" or "O"
+// - A move has already been made
+func (g *QuantumGame) ChooseSides(humanMark string, humanFirst bool) error {
+	if g.Mode != ComputerGame {
+		return errors.New("sides can only be chosen against the computer")
+	}
+	if humanMark != "X" && humanMark != "O" {
+		return fmt.Errorf("invalid mark %q: must be X or O", humanMark)
+	}
+	if len(g.marks) > 0 || g.pending >= 0 {
+		return errors.New("sides can only be chosen before the first move")
+	}
+
+	g.HumanMark = humanMark
+	g.ComputerMark = switchPlayer(humanMark)
+	g.CurrentPlayer = g.ComputerMark
+	if humanFirst {
+		g.CurrentPlayer = humanMark
+	}
+	return nil
+}
+
+// Turn returns the mark of the player about to move, or who must choose how
+// a cycle collapses.
+func (g *QuantumGame) Turn() string {
+	return g.CurrentPlayer
+}
+
+// Computer returns the computer's mark, or an empty string in a local game.
+func (g *QuantumGame) Computer() string {
+	return g.ComputerMark
+}
+
+// IsComputerTurn returns true if it's the computer's turn in a computer game.
+func (g *QuantumGame) IsComputerTurn() bool {
+	return g.Mode == ComputerGame && g.CurrentPlayer == g.ComputerMark
+}
+
+// Collapsing reports whether the player to move must choose how a cycle
+// collapses, rather than place a mark.
+func (g *QuantumGame) Collapsing() bool {
+	return g.collapse >= 0
+}
+
+// openSquares lists the squares no mark has collapsed into.
+func (g *QuantumGame) openSquares() []int {
+	var squares []int
+	for square, mark := range g.classical {
+		if mark < 0 {
+			squares = append(squares, square)
+		}
+	}
+	return squares
+}
+
+// lastSquare reports whether the next mark is classical, because only one
+// square is left open.
+func (g *QuantumGame) lastSquare() bool {
+	return g.collapse < 0 && len(g.openSquares()) == 1
+}
+
+// CheckMove returns the error MakeMove would give for the specified square,
+// or nil if it can be played.
+func (g *QuantumGame) CheckMove(rowIndex, columnIndex int) error {
+	if rowIndex < 0 || rowIndex >= MinSize || columnIndex < 0 || columnIndex >= MinSize {
+		return fmt.Errorf("invalid position: must be between 0 and %d", MinSize-1)
+	}
+	square := rowIndex*MinSize + columnIndex
+	if g.classical[square] >= 0 {
+		return errors.New("position already taken")
+	}
+	if g.collapse >= 0 {
+		mark := g.marks[g.collapse]
+		if square != mark.Cells[0] && square != mark.Cells[1] {
+			return fmt.Errorf("%s must collapse into %d or %d", mark.name(), mark.Cells[0]+1, mark.Cells[1]+1)
+		}
+	}
+	if square == g.pending {
+		return errors.New("the two halves of a mark must be in different squares")
+	}
+	return nil
+}
+
+// MakeMove plays the specified square: half of the current player's spooky
+// mark, the square a cycle collapses into, or the classical mark in the last
+// square left.
+// Returns an error if the square is off the board, already collapsed, not
+// one of the squares the cycle can collapse into, or the square the first
+// half of the mark was placed in.
+func (g *QuantumGame) MakeMove(rowIndex, columnIndex int) error {
+	if err := g.CheckMove(rowIndex, columnIndex); err != nil {
+		return err
+	}
+	g.play(rowIndex*MinSize + columnIndex)
+	return nil
+}
+
+// play makes a move to a square that has been checked.
+func (g *QuantumGame) play(square int) {
+	switch {
+	case g.collapse >= 0:
+		// The player who chose goes on to make their own move
+		g.collapseMark(g.collapse, square)
+		g.collapse = -1
+	case g.lastSquare():
+		g.marks = append(g.marks, quantumMark{Player: g.CurrentPlayer, Number: len(g.marks) + 1, Cells: [2]int{square, square}, Square: square})
+		g.classical[square] = len(g.marks) - 1
+		g.CurrentPlayer = switchPlayer(g.CurrentPlayer)
+	case g.pending < 0:
+		g.pending = square
+	default:
+		cycle := g.entangled(g.pending, square)
+		g.marks = append(g.marks, quantumMark{Player: g.CurrentPlayer, Number: len(g.marks) + 1, Cells: [2]int{g.pending, square}, Square: -1})
+		g.pending = -1
+		if cycle {
+			g.collapse = len(g.marks) - 1
+		}
+		g.CurrentPlayer = switchPlayer(g.CurrentPlayer)
+	}
+}
+
+// entangled reports whether two squares are joined by a chain of spooky
+// marks, so a mark between them closes a cycle.
+func (g *QuantumGame) entangled(from, to int) bool {
+	seen := map[int]bool{from: true}
+	queue := []int{from}
+	for len(queue) > 0 {
+		square := queue[0]
+		queue = queue[1:]
+		if square == to {
+			return true
+		}
+		for _, mark := range g.marks {
+			if mark.Square >= 0 {
+				continue
+			}
+			for i, cell := range mark.Cells {
+				if cell == square && !seen[mark.Cells[1-i]] {
+					seen[mark.Cells[1-i]] = true
+					queue = append(queue, mark.Cells[1-i])
+				}
+			}
+		}
+	}
+	return false
+}
+
+// collapseMark collapses the mark at index into square, which forces every
+// other spooky mark in that square into its other square, and so on.
+func (g *QuantumGame) collapseMark(index, square int) {
+	type placement struct{ index, square int }
+	queue := []placement{{index, square}}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if g.marks[next.index].Square >= 0 {
+			continue
+		}
+		g.marks[next.index].Square = next.square
+		g.classical[next.square] = next.index
+		for other, mark := range g.marks {
+			if mark.Square >= 0 {
+				continue
+			}
+			for i, cell := range mark.Cells {
+				if cell == next.square {
+					queue = append(queue, placement{other, mark.Cells[1-i]})
+				}
+			}
+		}
+	}
+}
+
+// Scores returns the points each player has scored: 1 for a line of three
+// classical marks, or when both players have a line, 1 for the line finished
+// earlier and ½ for the other. Both are zero while nobody has a line.
+func (g *QuantumGame) Scores() (x, o float64) {
+	xLine, oLine := g.earliestLine("X"), g.earliestLine("O")
+	switch {
+	case xLine == 0 && oLine == 0:
+		return 0, 0
+	case oLine == 0:
+		return 1, 0
+	case xLine == 0:
+		return 0, 1
+	case xLine < oLine:
+		return 1, 0.5
+	default:
+		return 0.5, 1
+	}
+}
+
+// earliestLine returns when the player's earliest line of three classical
+// marks was finished, as the highest move number in it, or 0 if they have no
+// line.
+func (g *QuantumGame) earliestLine(player string) int {
+	earliest := 0
+	for _, line := range quantumLines {
+		finished := 0
+		for _, square := range line {
+			index := g.classical[square]
+			if index < 0 || g.marks[index].Player != player {
+				finished = 0
+				break
+			}
+			finished = max(finished, g.marks[index].Number)
+		}
+		if finished > 0 && (earliest == 0 || finished < earliest) {
+			earliest = finished
+		}
+	}
+	return earliest
+}
+
+// GetWinner returns the mark of the player with the higher score once a line
+// of classical marks is made, or an empty string if there is none yet.
+func (g *QuantumGame) GetWinner() string {
+	x, o := g.Scores()
+	switch {
+	case x > o:
+		return "X"
+	case o > x:
+		return "O"
+	default:
+		return ""
+	}
+}
+
+// IsBoardFull reports whether every square has a classical mark.
+func (g *QuantumGame) IsBoardFull() bool {
+	return len(g.openSquares()) == 0
+}
+
+// UsesCoordinates reports that squares are numbered 1-9, as on the standard
+// board.
+func (g *QuantumGame) UsesCoordinates() bool {
+	return false
+}
+
+// PositionName returns the number of a square, from 1 to 9.
+func (g *QuantumGame) PositionName(rowIndex, columnIndex int) string {
+	return strconv.Itoa(rowIndex*MinSize + columnIndex + 1)
+}
+
+// GetAvailablePositions returns the numbers of the squares that can be
+// played now, in order.
+func (g *QuantumGame) GetAvailablePositions() []string {
+	var positions []string
+	for square := range quantumSquares {
+		if g.CheckMove(square/MinSize, square%MinSize) == nil {
+			positions = append(positions, strconv.Itoa(square+1))
+		}
+	}
+	return positions
+}
+
+// DescribeMove says what playing the specified square does, such as
+// "collapses X3 into 7", so the move can be announced before it is made.
+func (g *QuantumGame) DescribeMove(rowIndex, columnIndex int) string {
+	square := rowIndex*MinSize + columnIndex
+	next := g.CurrentPlayer + strconv.Itoa(len(g.marks)+1)
+	switch {
+	case g.collapse >= 0:
+		return fmt.Sprintf("collapses %s into %d", g.marks[g.collapse].name(), square+1)
+	case g.lastSquare():
+		return fmt.Sprintf("places %s at %d", next, square+1)
+	case g.pending < 0:
+		return fmt.Sprintf("places half of %s at %d", next, square+1)
+	default:
+		return fmt.Sprintf("places the other half of %s at %d", next, square+1)
+	}
+}
+
+// GetComputerMove chooses the computer's next square with a search a couple
+// of turns ahead, in which the player choosing each collapse picks the one
+// that suits them best. Positions the search does not finish are scored by
+// the lines each side can still complete with classical marks.
+// Returns row and column indices for the chosen square, or (-1, -1) if the
+// board is full.
+func (g *QuantumGame) GetComputerMove() (rowIndex, columnIndex int) {
+	return g.SuggestMove(g.Difficulty)
+}
+
+// SuggestMove chooses a square for the player about to move the way the
+// computer would at the given difficulty, whoever that player is. For a
+// spooky mark it chooses both squares at once and returns the second when
+// asked again.
+// Returns (-1, -1) if the board is full.
+func (g *QuantumGame) SuggestMove(difficulty Difficulty) (rowIndex, columnIndex int) {
+	open := g.openSquares()
+	if len(open) == 0 {
+		return -1, -1
+	}
+
+	level := Levels[difficulty]
+	depth := level.Depth
+	if depth == 0 || depth > quantumDepth {
+		depth = quantumDepth
+	}
+	mistake := level.MistakeRate > 0 && g.rng.Float64() < level.MistakeRate
+
+	var square int
+	switch {
+	case g.collapse >= 0:
+		square = g.chooseCollapse(depth, mistake)
+	case g.lastSquare():
+		square = open[0]
+	case g.pending >= 0 && g.plan[0] == g.pending:
+		square = g.plan[1]
+	default:
+		g.plan = g.choosePair(depth, mistake)
+		square = g.plan[0]
+		if g.pending >= 0 {
+			square = g.plan[1]
+		}
+	}
+	return square / MinSize, square % MinSize
+}
+
+// chooseCollapse picks the square the mark waiting to collapse goes into.
+func (g *QuantumGame) chooseCollapse(depth int, mistake bool) int {
+	cells := g.marks[g.collapse].Cells
+	if mistake {
+		return cells[g.rng.Intn(len(cells))]
+	}
+	best, bestScore := cells[0], -2*winScore
+	for _, square := range cells {
+		child := g.clone()
+		child.play(square)
+		if score := child.value(g.CurrentPlayer, depth); score > bestScore {
+			best, bestScore = square, score
+		}
+	}
+	return best
+}
+
+// choosePair picks the two squares of the current player's spooky mark. If
+// the first half has already been placed, the first square is that one.
+func (g *QuantumGame) choosePair(depth int, mistake bool) [2]int {
+	pairs := g.pairs()
+	g.rng.Shuffle(len(pairs), func(i, j int) {
+		pairs[i], pairs[j] = pairs[j], pairs[i]
+	})
+	if mistake {
+		return pairs[0]
+	}
+
+	best, bestScore := pairs[0], -2*winScore
+	for _, pair := range pairs {
+		if score := g.scorePair(pair, depth); score > bestScore {
+			best, bestScore = pair, score
+		}
+	}
+	return best
+}
+
+// pairs lists the pairs of squares the current player's spooky mark can be
+// placed in, keeping the first half where it is if it has been placed.
+func (g *QuantumGame) pairs() [][2]int {
+	open := g.openSquares()
+	var pairs [][2]int
+	for _, first := range open {
+		if g.pending >= 0 && first != g.pending {
+			continue
+		}
+		for _, second := range open {
+			if second == first || (g.pending < 0 && second < first) {
+				continue
+			}
+			pairs = append(pairs, [2]int{first, second})
+		}
+	}
+	return pairs
+}
+
+// scorePair scores placing the current player's spooky mark in pair for that
+// player, depth turns ahead.
+func (g *QuantumGame) scorePair(pair [2]int, depth int) int {
+	child := g.clone()
+	if child.pending < 0 {
+		child.play(pair[0])
+	}
+	child.play(pair[1])
+	if child.collapse < 0 {
+		return -child.value(child.CurrentPlayer, depth-1)
+	}
+
+	// The opponent chooses the collapse that is worst for the player
+	worst := 2 * winScore
+	for _, square := range child.marks[child.collapse].Cells {
+		collapsed := child.clone()
+		collapsed.play(square)
+		worst = min(worst, -collapsed.value(collapsed.CurrentPlayer, depth-1))
+	}
+	return worst
+}
+
+// value scores the position for player, who is about to move, searching
+// depth turns ahead.
+func (g *QuantumGame) value(player string, depth int) int {
+	if x, o := g.Scores(); x > 0 || o > 0 {
+		if player == "O" {
+			x, o = o, x
+		}
+		return int((x - o) * winScore)
+	}
+	if g.IsBoardFull() {
+		return 0
+	}
+	if depth <= 0 {
+		return g.evaluate(player)
+	}
+	if g.lastSquare() {
+		child := g.clone()
+		child.play(g.openSquares()[0])
+		return -child.value(child.CurrentPlayer, depth-1)
+	}
+
+	best := -2 * winScore
+	for _, pair := range g.pairs() {
+		best = max(best, g.scorePair(pair, depth))
+	}
+	return best
+}
+
+// evaluate scores an unfinished position for player by the lines each side
+// could still complete with classical marks.
+func (g *QuantumGame) evaluate(player string) int {
+	return g.classicalBoard().lineScore(player, MinSize)
+}
+
+// classicalBoard returns the board of classical marks.
+func (g *QuantumGame) classicalBoard() Board {
+	board := NewBoard(MinSize)
+	for square, index := range g.classical {
+		if index >= 0 {
+			board[square/MinSize][square%MinSize] = g.marks[index].Player
+		}
+	}
+	return board
+}
+
+// clone returns a copy of the game the computer can play moves on while it
+// searches.
+func (g *QuantumGame) clone() *QuantumGame {
+	clone := *g
+	clone.marks = slices.Clone(g.marks)
+	return &clone
+}
+
+// String returns the board with each square drawn three lines high. An open
+// square shows its number and the spooky marks in it, with the half of a
+// mark still being placed highlighted; a collapsed square shows its
+// classical mark. Below the board a line says what the player to move must
+// do, or once the game is over, the scores.
+func (g *QuantumGame) String() string {
+	var sb strings.Builder
+	sb.WriteString("\n")
+	for boardRow := range MinSize {
+		if boardRow > 0 {
+			separator := strings.Repeat("-", quantumCellWidth+2)
+			sb.WriteString(strings.Join([]string{separator, separator, separator}, "+") + "\n")
+		}
+		cells := make([][]string, MinSize)
+		for boardColumn := range MinSize {
+			cells[boardColumn] = g.cellLines(boardRow*MinSize + boardColumn)
+		}
+		for line := range MinSize {
+			parts := make([]string, MinSize)
+			for boardColumn := range MinSize {
+				parts[boardColumn] = " " + cells[boardColumn][line] + " "
+			}
+			sb.WriteString(strings.Join(parts, "|") + "\n")
+		}
+	}
+	sb.WriteString(g.status())
+	return sb.String()
+}
+
+// cellLines draws a square as three lines of quantumCellWidth characters.
+func (g *QuantumGame) cellLines(square int) []string {
+	blank := strings.Repeat(" ", quantumCellWidth)
+	if index := g.classical[square]; index >= 0 {
+		name := g.marks[index].name()
+		padding := (quantumCellWidth - len(name)) / 2
+		centered := strings.Repeat(" ", padding) + RenderMark(g.marks[index].Player) + name[1:] + strings.Repeat(" ", quantumCellWidth-padding-len(name))
+		return []string{blank, centered, blank}
+	}
+
+	// The square's number comes first, then up to eight spooky marks, three
+	// to a line
+	tokens := []string{theme.Muted.Render(strconv.Itoa(square+1)) + " "}
+	for _, mark := range g.marks {
+		if mark.Square < 0 && (mark.Cells[0] == square || mark.Cells[1] == square) {
+			tokens = append(tokens, RenderMark(strings.ToLower(mark.Player))+strconv.Itoa(mark.Number))
+		}
+	}
+	if g.pending == square {
+		tokens = append(tokens, theme.Highlight.Render(strings.ToLower(g.CurrentPlayer)+strconv.Itoa(len(g.marks)+1)))
+	}
+
+	lines := []string{blank, blank, blank}
+	for line := range lines {
+		start := line * MinSize
+		if start >= len(tokens) {
+			break
+		}
+		end := min(start+MinSize, len(tokens))
+		// Every token is two characters wide, and they are separated by spaces
+		width := 3*(end-start) - 1
+		lines[line] = strings.Join(tokens[start:end], " ") + strings.Repeat(" ", quantumCellWidth-width)
+	}
+	return lines
+}
+
+// status says what the player to move must do, or the scores once the game
+// is over.
+func (g *QuantumGame) status() string {
+	if x, o := g.Scores(); x > 0 || o > 0 {
+		return fmt.Sprintf("Score: X %s, O %s\n", formatPoints(x), formatPoints(o))
+	}
+	next := g.CurrentPlayer + strconv.Itoa(len(g.marks)+1)
+	switch {
+	case g.IsBoardFull():
+		return ""
+	case g.collapse >= 0:
+		mark := g.marks[g.collapse]
+		return fmt.Sprintf("%s closed a cycle: %s chooses whether it collapses into %d or %d\n", mark.name(), g.CurrentPlayer, mark.Cells[0]+1, mark.Cells[1]+1)
+	case g.lastSquare():
+		return fmt.Sprintf("Only %d is left: %s goes there\n", g.openSquares()[0]+1, next)
+	case g.pending >= 0:
+		return fmt.Sprintf("Choose the second square for %s\n", next)
+	default:
+		return fmt.Sprintf("Choose two squares for %s\n", next)
+	}
+}
+
+// formatPoints writes a score of whole and half points, such as "1" or "½".
+func formatPoints(points float64) string {
+	if points == 0.5 {
+		return "½"
+	}
+	return strconv.FormatFloat(points, 'f', -1, 64)
+}
@@ -0,0 +1,205 @@
+package tictactoe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chrisreddington/gh-game/internal/output"
+)
+
+// playQuantum plays each square, numbered 1-9, failing the test if any is
+// rejected.
+func playQuantum(t *testing.T, game *QuantumGame, squares ...int) {
+	t.Helper()
+	for _, square := range squares {
+		if err := game.MakeMove((square-1)/3, (square-1)%3); err != nil {
+			t.Fatalf("MakeMove(%d) unexpected error: %v", square, err)
+		}
+	}
+}
+
+// setClassical collapses a mark by player with the given number straight
+// into square, numbered 1-9.
+func setClassical(game *QuantumGame, player string, number, square int) {
+	game.marks = append(game.marks, quantumMark{Player: player, Number: number, Cells: [2]int{square - 1, square - 1}, Square: square - 1})
+	game.classical[square-1] = len(game.marks) - 1
+}
+
+func TestQuantumGame_SpookyMarks(t *testing.T) {
+	game := NewQuantumGame(LocalGame, newTestRand())
+
+	playQuantum(t, game, 1)
+	if game.Turn() != "X" {
+		t.Errorf("Turn() = %s after the first half of X1, want X to place the second", game.Turn())
+	}
+	if err := game.MakeMove(0, 0); err == nil {
+		t.Error("MakeMove() put both halves of X1 in square 1")
+	}
+	playQuantum(t, game, 5)
+	if game.Turn() != "O" {
+		t.Errorf("Turn() = %s after X1, want O", game.Turn())
+	}
+
+	// Square 1 can still be played, since X1 may yet collapse into 5
+	if positions := game.GetAvailablePositions(); len(positions) != 9 {
+		t.Errorf("GetAvailablePositions() = %v, want every square", positions)
+	}
+	board := game.String()
+	if !strings.Contains(board, "x1") || !strings.Contains(board, "Choose two squares for O2") {
+		t.Errorf("String() = %q, want X1 shown spooky and O asked for O2", board)
+	}
+}
+
+func TestQuantumGame_Collapse(t *testing.T) {
+	game := NewQuantumGame(LocalGame, newTestRand())
+
+	// X1 in 1 and 2, O2 in 2 and 3, then X3 in 3 and 1 closes a cycle
+	playQuantum(t, game, 1, 2, 2, 3)
+	if game.Collapsing() {
+		t.Fatal("Collapsing() = true before any cycle")
+	}
+	playQuantum(t, game, 3, 1)
+	if !game.Collapsing() || game.Turn() != "O" {
+		t.Fatalf("Collapsing() = %v with %s to choose, want O to choose the collapse", game.Collapsing(), game.Turn())
+	}
+	if positions := game.GetAvailablePositions(); strings.Join(positions, " ") != "1 3" {
+		t.Errorf("GetAvailablePositions() = %v, want X3's squares 1 and 3", positions)
+	}
+	if err := game.MakeMove(1, 1); err == nil || !strings.Contains(err.Error(), "X3 must collapse into 3 or 1") {
+		t.Errorf("MakeMove(5) error = %v, want one naming X3's squares", err)
+	}
+	if description := game.DescribeMove(0, 0); description != "collapses X3 into 1" {
+		t.Errorf("DescribeMove(1) = %q, want \"collapses X3 into 1\"", description)
+	}
+
+	// X3 in 1 pushes X1 into 2, which pushes O2 into 3
+	playQuantum(t, game, 1)
+	want := map[int]string{0: "X3", 1: "X1", 2: "O2"}
+	for square, name := range want {
+		if index := game.classical[square]; index < 0 || game.marks[index].name() != name {
+			t.Errorf("square %d holds mark %d, want %s", square+1, index, name)
+		}
+	}
+	if game.Collapsing() || game.Turn() != "O" {
+		t.Errorf("after the collapse Collapsing() = %v with %s to move, want O to move", game.Collapsing(), game.Turn())
+	}
+	if err := game.MakeMove(0, 0); err == nil {
+		t.Error("MakeMove() played a collapsed square")
+	}
+}
+
+func TestQuantumGame_Scores(t *testing.T) {
+	tests := []struct {
+		name         string
+		x, o         []int // the squares of each player's classical marks, in move order
+		wantX, wantO float64
+		wantWinner   string
+	}{
+		{name: "no line", x: []int{1, 5}, o: []int{2}, wantWinner: ""},
+		{name: "single line", x: []int{1, 2, 3}, o: []int{4, 5}, wantX: 1, wantWinner: "X"},
+		// X's line is finished by X5, before O's by O6
+		{name: "both lines, X first", x: []int{1, 2, 3}, o: []int{7, 8, 9}, wantX: 1, wantO: 0.5, wantWinner: "X"},
+		// X's line needs X7, after O6 finished O's
+		{name: "both lines, O first", x: []int{1, 2, 5, 3}, o: []int{7, 8, 9}, wantX: 0.5, wantO: 1, wantWinner: "O"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewQuantumGame(LocalGame, newTestRand())
+			// Marks are numbered as if the players took turns, X first
+			for i, square := range tt.x {
+				setClassical(game, "X", 2*i+1, square)
+			}
+			for i, square := range tt.o {
+				setClassical(game, "O", 2*i+2, square)
+			}
+
+			x, o := game.Scores()
+			if x != tt.wantX || o != tt.wantO {
+				t.Errorf("Scores() = %v, %v, want %v, %v", x, o, tt.wantX, tt.wantO)
+			}
+			if winner := game.GetWinner(); winner != tt.wantWinner {
+				t.Errorf("GetWinner() = %q, want %q", winner, tt.wantWinner)
+			}
+		})
+	}
+
+	game := NewQuantumGame(LocalGame, newTestRand())
+	for i, square := range []int{1, 2, 3} {
+		setClassical(game, "X", 2*i+1, square)
+	}
+	for i, square := range []int{7, 8, 9} {
+		setClassical(game, "O", 2*i+2, square)
+	}
+	if board := game.String(); !strings.Contains(board, "Score: X 1, O ½") {
+		t.Errorf("String() = %q, want the score shown", board)
+	}
+}
+
+func TestQuantumGame_LastSquare(t *testing.T) {
+	game := NewQuantumGame(LocalGame, newTestRand())
+	// X O X / X O O / O X . leaves only square 9, with no line
+	for i, square := range []int{1, 2, 3, 4, 5, 6, 7, 8} {
+		player := "XOXXOOOX"[i : i+1]
+		setClassical(game, player, i+1, square)
+	}
+	game.CurrentPlayer = "X"
+
+	if description := game.DescribeMove(2, 2); description != "places X9 at 9" {
+		t.Errorf("DescribeMove(9) = %q, want X9 placed directly", description)
+	}
+	playQuantum(t, game, 9)
+	if !game.IsBoardFull() || game.marks[8].Square != 8 {
+		t.Errorf("after the last move IsBoardFull() = %v and X9 is in %d, want a full board with X9 in 9", game.IsBoardFull(), game.marks[8].Square+1)
+	}
+}
+
+func TestQuantumGame_ComputerMove(t *testing.T) {
+	game := NewQuantumGame(ComputerGame, newTestRand())
+	if err := game.ChooseSides("X", false); err != nil {
+		t.Fatalf("ChooseSides() unexpected error: %v", err)
+	}
+
+	// The computer picks both squares of O1 at once, and plays them in turn
+	for range 2 {
+		rowIndex, columnIndex := game.GetComputerMove()
+		if err := game.MakeMove(rowIndex, columnIndex); err != nil {
+			t.Fatalf("MakeMove(%d,%d) unexpected error: %v", rowIndex, columnIndex, err)
+		}
+	}
+	if len(game.marks) != 1 || game.Turn() != "X" {
+		t.Errorf("after the computer's move there are %d marks with %s to move, want O1 placed and X to move", len(game.marks), game.Turn())
+	}
+	if err := game.ChooseSides("O", true); err == nil {
+		t.Error("ChooseSides() after the first move did not return an error")
+	}
+}
+
+func TestQuantumGame_ComputerTakesTheWin(t *testing.T) {
+	// X has 1 and 2 and a spooky X5 in 3 and 9. Placing X7 in 3 and 9 too
+	// closes a cycle, and however O collapses it one of X's marks lands in 3
+	game := NewQuantumGame(LocalGame, newTestRand())
+	setClassical(game, "X", 1, 1)
+	setClassical(game, "O", 2, 4)
+	setClassical(game, "X", 3, 2)
+	setClassical(game, "O", 4, 7)
+	game.marks = append(game.marks,
+		quantumMark{Player: "X", Number: 5, Cells: [2]int{2, 8}, Square: -1},
+		quantumMark{Player: "O", Number: 6, Cells: [2]int{4, 5}, Square: -1},
+	)
+	game.CurrentPlayer = "X"
+
+	var text strings.Builder
+	winner, err := NewSession(game, ComputerPlayer{Difficulty: Perfect}, ComputerPlayer{Difficulty: Perfect}, output.NewText(&text)).Play()
+	if err != nil {
+		t.Fatalf("Play() unexpected error: %v", err)
+	}
+	if winner != "X" {
+		t.Errorf("Play() winner = %q, want X to force the win", winner)
+	}
+	for _, want := range []string{"Computer places half of X7 at", "Computer collapses X7 into"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("Play() output = %q, want it to contain %q", text.String(), want)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package tictactoe
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chrisreddington/gh-game/internal/output"
+)
+
+// Player chooses the moves for one side of a game.
+type Player interface {
+	// Kind names the kind of player, such as "human" or "computer". It is
+	// reported with each move, and the moves of every kind of player but a
+	// human, who sees their own choice, are announced.
+	Kind() string
+	// ChooseMove returns the row and column of the player's next move.
+	// A player may return ErrUndo or ErrRedo instead to take a turn back or
+	// play it again.
+	ChooseMove(game Playable) (rowIndex, columnIndex int, err error)
+}
+
+// HumanPlayer is a player who chooses their moves at a prompt.
+type HumanPlayer struct {
+	Prompter Prompter // Prompter asks the player for their moves
+}
+
+// Kind implements Player.
+func (p HumanPlayer) Kind() string {
+	return "human"
+}
+
+// ChooseMove implements Player by asking the player for their move with
+// GetPlayerMove.
+func (p HumanPlayer) ChooseMove(game Playable) (rowIndex, columnIndex int, err error) {
+	return GetPlayerMove(p.Prompter, game)
+}
+
+// ComputerPlayer is the built-in computer opponent.
+type ComputerPlayer struct {
+	Difficulty Difficulty // Difficulty sets how well the computer plays
+}
+
+// Kind implements Player.
+func (p ComputerPlayer) Kind() string {
+	return "computer"
+}
+
+// ChooseMove implements Player by searching for the best move at the
+// player's difficulty.
+func (p ComputerPlayer) ChooseMove(game Playable) (rowIndex, columnIndex int, err error) {
+	rowIndex, columnIndex = game.SuggestMove(p.Difficulty)
+	if rowIndex < 0 {
+		return -1, -1, errors.New("no available moves")
+	}
+	return rowIndex, columnIndex, nil
+}
+
+// Session plays a game between two players, showing the board before each
+// move and announcing the result.
+type Session struct {
+	game    Playable
+	players map[string]Player
+	out     output.Output
+}
+
+// NewSession creates a session of game between the player of X and the
+// player of O, which reports the game to out.
+func NewSession(game Playable, x, o Player, out output.Output) *Session {
+	return &Session{
+		game:    game,
+		players: map[string]Player{"X": x, "O": o},
+		out:     out,
+	}
+}
+
+// Play asks each player for their move in turn until the game is won or
+// drawn. Each move is reported as a "move" event and the end of the game as
+// a "game_over" event.
+// Returns the winner's mark, or an empty string for a draw, and an error if
+// a player could not choose a move. A human who chooses a move that is not
+// allowed is asked again, but any other player's invalid move ends the game
+// with an error, as asking again would get the same move.
+func (s *Session) Play() (winner string, err error) {
+	out := s.out
+	game := s.game
+
+	for {
+		out.Println(game)
+		currentMark := game.Turn()
+		out.Printf("Player %s's turn\n", RenderMark(currentMark))
+
+		player := s.players[currentMark]
+		rowIndex, columnIndex, err := player.ChooseMove(game)
+		if errors.Is(err, ErrUndo) || errors.Is(err, ErrRedo) {
+			s.rewind(errors.Is(err, ErrUndo))
+			continue
+		}
+		if err != nil {
+			out.Printf("Error getting move: %v\n", err)
+			return "", err
+		}
+
+		description := ""
+		if describer, ok := game.(moveDescriber); ok {
+			description = describer.DescribeMove(rowIndex, columnIndex)
+		}
+		if err := game.MakeMove(rowIndex, columnIndex); err != nil {
+			out.Printf("Invalid move: %v\n", err)
+			if player.Kind() != "human" {
+				return "", err
+			}
+			continue
+		}
+		placed := placedMark(game, currentMark)
+		if player.Kind() != "human" {
+			if description == "" {
+				description = fmt.Sprintf("places %s at %s", RenderMark(placed), game.PositionName(rowIndex, columnIndex))
+			}
+			out.Printf("%s %s\n", kindName(player), description)
+		}
+		fields := output.Fields{
+			"player":   player.Kind(),
+			"mark":     currentMark,
+			"position": game.PositionName(rowIndex, columnIndex),
+		}
+		if placed != currentMark {
+			fields["placed"] = placed
+		}
+		out.Event("move", fields)
+
+		// Check win condition
+		if winner := game.GetWinner(); winner != "" {
+			out.Event("game_over", output.Fields{"winner": winner})
+			out.Println(game)
+			if winnerPlayer := s.players[winner]; winnerPlayer.Kind() != "human" {
+				out.Printf("%s (%s) wins!\n", kindName(winnerPlayer), RenderMark(winner))
+			} else {
+				out.Printf("Player %s wins!\n", RenderMark(winner))
+			}
+			return winner, nil
+		}
+
+		// Check draw condition
+		if game.IsBoardFull() {
+			out.Event("game_over", output.Fields{"winner": ""})
+			out.Println(game)
+			out.Println("It's a draw!")
+			return "", nil
+		}
+	}
+}
+
+// rewind takes the last turn back, or plays the last turn taken back again,
+// as the player asked.
+func (s *Session) rewind(undo bool) {
+	rewindable, ok := s.game.(Rewindable)
+	if !ok {
+		return
+	}
+
+	action, verb := "redo", "Played again"
+	rewind := rewindable.Redo
+	if undo {
+		action, verb = "undo", "Took back"
+		rewind = rewindable.Undo
+	}
+	moves, err := rewind()
+	if err != nil {
+		s.out.Printf("Could not %s: %v\n", action, err)
+		return
+	}
+
+	names := make([]string, len(moves))
+	for i, move := range moves {
+		names[i] = move.Mark + s.game.PositionName(move.RowIndex, move.ColumnIndex)
+	}
+	s.out.Event(action, output.Fields{"moves": names})
+	s.out.Printf("%s %s\n", verb, strings.Join(names, " "))
+}
+
+// moveDescriber is implemented by games whose moves do more than place the
+// mover's mark on a square, so they can say what a move does before it is
+// made.
+type moveDescriber interface {
+	DescribeMove(rowIndex, columnIndex int) string
+}
+
+// placedMark returns the mark the last move placed. That is the mover's own
+// mark unless the game records its moves and they say otherwise, as in Wild
+// and Notakto.
+func placedMark(game Playable, mark string) string {
+	recorded, ok := game.(interface{ Moves() []Move })
+	if !ok {
+		return mark
+	}
+	if moves := recorded.Moves(); len(moves) > 0 {
+		return moves[len(moves)-1].Mark
+	}
+	return mark
+}
+
+// kindName returns the player's kind capitalised for the start of a sentence,
+// such as "Computer".
+func kindName(player Player) string {
+	kind := player.Kind()
+	if kind == "" {
+		return "Player"
+	}
+	return strings.ToUpper(kind[:1]) + kind[1:]
+}
+
+// DelayedPlayer pauses before each move of the player it wraps, so someone
+// watching the game can follow it.
+type DelayedPlayer struct {
+	Player               // Player chooses the moves
+	Delay  time.Duration // Delay is how long to pause before each move
+}
+
+// ChooseMove implements Player by pausing for the delay, then asking the
+// wrapped player for its move.
+func (p DelayedPlayer) ChooseMove(game Playable) (rowIndex, columnIndex int, err error) {
+	time.Sleep(p.Delay)
+	return p.Player.ChooseMove(game)
+}
@@ -0,0 +1,198 @@
+package tictactoe
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrisreddington/gh-game/internal/output"
+)
+
+// scriptedPlayer plays a fixed list of moves, then fails.
+type scriptedPlayer struct {
+	kind  string
+	moves [][2]int
+	err   error // err is returned instead of a move once the moves run out
+}
+
+// Kind implements Player.
+func (p *scriptedPlayer) Kind() string {
+	return p.kind
+}
+
+// ChooseMove implements Player by returning the next scripted move.
+func (p *scriptedPlayer) ChooseMove(game Playable) (rowIndex, columnIndex int, err error) {
+	if len(p.moves) == 0 {
+		if p.err != nil {
+			return -1, -1, p.err
+		}
+		return -1, -1, errors.New("no more moves")
+	}
+	move := p.moves[0]
+	p.moves = p.moves[1:]
+	return move[0], move[1], nil
+}
+
+// eventNames returns the name of every event in JSON lines output.
+func eventNames(lines string) []string {
+	var names []string
+	for _, line := range strings.Split(strings.TrimSpace(lines), "\n") {
+		if _, name, ok := strings.Cut(line, `{"event":"`); ok {
+			name, _, _ = strings.Cut(name, `"`)
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func TestSession_Play(t *testing.T) {
+	tests := []struct {
+		name       string
+		x, o       *scriptedPlayer
+		wantWinner string
+		wantText   []string
+	}{
+		{
+			name:       "human wins",
+			x:          &scriptedPlayer{kind: "human", moves: [][2]int{{0, 0}, {0, 1}, {0, 2}}},
+			o:          &scriptedPlayer{kind: "human", moves: [][2]int{{1, 0}, {1, 1}}},
+			wantWinner: "X",
+			wantText:   []string{"Player X's turn", "Player O's turn", "Player X wins!"},
+		},
+		{
+			name:       "computer wins and its moves are announced",
+			x:          &scriptedPlayer{kind: "human", moves: [][2]int{{0, 0}, {0, 1}, {2, 2}}},
+			o:          &scriptedPlayer{kind: "computer", moves: [][2]int{{1, 0}, {1, 1}, {1, 2}}},
+			wantWinner: "O",
+			wantText:   []string{"Computer places O at 4", "Computer (O) wins!"},
+		},
+		{
+			name:     "draw",
+			x:        &scriptedPlayer{kind: "human", moves: [][2]int{{0, 0}, {0, 2}, {1, 0}, {2, 1}, {1, 2}}},
+			o:        &scriptedPlayer{kind: "human", moves: [][2]int{{0, 1}, {1, 1}, {2, 0}, {2, 2}}},
+			wantText: []string{"It's a draw!"},
+		},
+		{
+			name:       "invalid moves are asked for again",
+			x:          &scriptedPlayer{kind: "human", moves: [][2]int{{0, 0}, {1, 0}, {0, 1}, {0, 2}}},
+			o:          &scriptedPlayer{kind: "human", moves: [][2]int{{0, 0}, {1, 0}, {1, 1}}},
+			wantWinner: "X",
+			wantText:   []string{"Invalid move: position already taken"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var text bytes.Buffer
+			winner, err := NewSession(NewGame(LocalGame, newTestRand()), tt.x, tt.o, output.NewText(&text)).Play()
+			if err != nil {
+				t.Fatalf("Play() unexpected error: %v", err)
+			}
+			if winner != tt.wantWinner {
+				t.Errorf("Play() winner = %q, want %q", winner, tt.wantWinner)
+			}
+			for _, want := range tt.wantText {
+				if !strings.Contains(text.String(), want) {
+					t.Errorf("Play() output = %q, want it to contain %q", text.String(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestSession_Events(t *testing.T) {
+	var lines bytes.Buffer
+	x := &scriptedPlayer{kind: "human", moves: [][2]int{{0, 0}, {0, 1}, {0, 2}}}
+	o := &scriptedPlayer{kind: "computer", moves: [][2]int{{1, 0}, {1, 1}}}
+	if _, err := NewSession(NewGame(LocalGame, newTestRand()), x, o, output.NewJSON(&lines)).Play(); err != nil {
+		t.Fatalf("Play() unexpected error: %v", err)
+	}
+
+	want := []string{"move", "move", "move", "move", "move", "game_over"}
+	if got := eventNames(lines.String()); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Play() events = %v, want %v", got, want)
+	}
+	if !strings.Contains(lines.String(), `{"event":"move","mark":"O","player":"computer","position":"4"}`) {
+		t.Errorf("Play() events = %s, want the computer's move at 4", lines.String())
+	}
+}
+
+func TestSession_PlayerError(t *testing.T) {
+	var text bytes.Buffer
+	failure := errors.New("prompt closed")
+	x := &scriptedPlayer{kind: "human", err: failure}
+	o := &scriptedPlayer{kind: "human"}
+
+	if _, err := NewSession(NewGame(LocalGame, newTestRand()), x, o, output.NewText(&text)).Play(); !errors.Is(err, failure) {
+		t.Errorf("Play() error = %v, want %v", err, failure)
+	}
+	if !strings.Contains(text.String(), "Error getting move: prompt closed") {
+		t.Errorf("Play() output = %q, want the error reported", text.String())
+	}
+}
+
+func TestSession_InvalidComputerMove(t *testing.T) {
+	var text bytes.Buffer
+	// The computer's move on 1 is taken, and it is not asked again
+	x := &scriptedPlayer{kind: "human", moves: [][2]int{{0, 0}, {0, 1}}}
+	o := &scriptedPlayer{kind: "computer", moves: [][2]int{{0, 0}, {1, 1}}}
+
+	_, err := NewSession(NewGame(LocalGame, newTestRand()), x, o, output.NewText(&text)).Play()
+	if err == nil || err.Error() != "position already taken" {
+		t.Errorf("Play() error = %v, want position already taken", err)
+	}
+	if len(o.moves) != 1 {
+		t.Errorf("Play() asked the computer for %d moves, want 1", 2-len(o.moves))
+	}
+}
+
+func TestSession_HumanUndoesAgainstComputer(t *testing.T) {
+	var text bytes.Buffer
+	game := NewGame(ComputerGame, newTestRand())
+	// The human plays 1, takes it back along with the computer's reply,
+	// then plays 5 and is out of answers
+	human := HumanPlayer{Prompter: &mockPrompter{selectAnswers: []int{0, 7, 4}}}
+
+	_, err := NewSession(game, human, ComputerPlayer{Difficulty: Perfect}, output.NewText(&text)).Play()
+	if err == nil {
+		t.Fatal("Play() did not report the prompter running out of answers")
+	}
+	if !strings.Contains(text.String(), "Took back X1 O5") {
+		t.Errorf("Play() output = %q, want the human's and computer's moves taken back", text.String())
+	}
+	if moves := game.Moves(); len(moves) != 2 || moves[0] != (Move{Player: "X", Mark: "X", RowIndex: 1, ColumnIndex: 1}) {
+		t.Errorf("Moves() = %v, want X5 and the computer's reply", moves)
+	}
+}
+
+func TestSession_ComputerPlaysItself(t *testing.T) {
+	game, err := NewSizedGame(LocalGame, 3, 3, newTestRand())
+	if err != nil {
+		t.Fatalf("NewSizedGame() unexpected error: %v", err)
+	}
+	winner, err := NewSession(game, ComputerPlayer{Difficulty: Perfect}, ComputerPlayer{Difficulty: Perfect}, output.Discard).Play()
+	if err != nil {
+		t.Fatalf("Play() unexpected error: %v", err)
+	}
+	if winner != "" {
+		t.Errorf("Play() winner = %q, want perfect play from both sides to draw", winner)
+	}
+}
+
+func TestDelayedPlayer(t *testing.T) {
+	player := DelayedPlayer{Player: &scriptedPlayer{kind: "computer", moves: [][2]int{{2, 1}}}, Delay: time.Millisecond}
+	if kind := player.Kind(); kind != "computer" {
+		t.Errorf("Kind() = %q, want the wrapped player's kind", kind)
+	}
+
+	start := time.Now()
+	rowIndex, columnIndex, err := player.ChooseMove(NewGame(LocalGame, newTestRand()))
+	if err != nil || rowIndex != 2 || columnIndex != 1 {
+		t.Errorf("ChooseMove() = (%d,%d), %v, want the wrapped player's move (2,1)", rowIndex, columnIndex, err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Millisecond {
+		t.Errorf("ChooseMove() returned after %s, want a pause of at least 1ms", elapsed)
+	}
+}
@@ -1,13 +1,31 @@
 // Package tictactoe implements a classic Tic-tac-toe game where players can play
-// against another player locally or against a computer opponent.
+// against another player locally or against a computer opponent. It also
+// implements ultimate tic-tac-toe, played on a 3x3 grid of small boards, the
+// Misère and Wild variants of the classic game, Notakto and quantum
+// tic-tac-toe.
 package tictactoe
 
 import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"strconv"
+	"strings"
 
-	"github.com/charmbracelet/lipgloss"
+	"github.com/chrisreddington/gh-game/internal/theme"
+)
+
+// Variant is a set of rules for playing on a single board.
+type Variant int
+
+const (
+	// Standard is the classic game, won by the first player to get a line
+	Standard Variant = iota
+	// Misere is lost by the first player to complete a line
+	Misere
+	// Wild lets each player place X or O on every move, and is won by the
+	// player who completes a line of either mark
+	Wild
 )
 
 // GameMode represents the type of game being played (local multiplayer or against computer)
@@ -21,15 +39,40 @@ const (
 )
 
 var (
-	// Use accessible colors - blue for X and yellow for O
-	// These colors have good contrast and are distinguishable for most types of color blindness
-	xStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("45"))  // bright blue
-	oStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("226")) // bright yellow
+	// X and O take the theme's two player styles, so they stay distinguishable
+	// in every theme
+	xStyle = theme.Player1
+	oStyle = theme.Player2
+)
+
+// RenderMark styles a player's mark for display.
+func RenderMark(mark string) string {
+	if mark == "O" {
+		return oStyle.Render(mark)
+	}
+	return xStyle.Render(mark)
+}
+
+var (
+	// ErrUndo is returned by GetPlayerMove when the player asks to take back
+	// their last turn instead of moving
+	ErrUndo = errors.New("undo requested")
+	// ErrRedo is returned by GetPlayerMove when the player asks to play a
+	// turn they took back again
+	ErrRedo = errors.New("redo requested")
+)
+
+const (
+	// undoOption and redoOption are offered alongside the squares when a
+	// player can take a turn back or play it again
+	undoOption = "Undo"
+	redoOption = "Redo"
 )
 
 // Prompter defines an interface for getting user input
 type Prompter interface {
 	Select(prompt, defaultValue string, options []string) (int, error)
+	Input(prompt, defaultValue string) (string, error)
 }
 
 // GameInterface defines the methods needed for GetPlayerMove
@@ -37,99 +80,319 @@ type GameInterface interface {
 	GetAvailablePositions() []string
 }
 
-// Board represents a 3x3 Tic-tac-toe game board.
-// Empty squares are represented by empty strings,
+// markChooser is implemented by games where players may choose which mark
+// to place on each move.
+type markChooser interface {
+	ChoosesMark() bool
+	ChooseMark(mark string) error
+}
+
+// Rewindable is implemented by games whose turns can be taken back and
+// played again.
+type Rewindable interface {
+	CanUndo() bool
+	CanRedo() bool
+	Undo() ([]Move, error)
+	Redo() ([]Move, error)
+}
+
+// coordinateGame is implemented by games whose squares are named by
+// coordinates such as "c7", which are typed in rather than picked from a list.
+type coordinateGame interface {
+	UsesCoordinates() bool
+	ParsePosition(name string) (rowIndex, columnIndex int, err error)
+	PositionName(rowIndex, columnIndex int) string
+	CheckMove(rowIndex, columnIndex int) error
+}
+
+// Playable is a game of tic-tac-toe in any variant, as seen by the loop that
+// asks each side for its move.
+type Playable interface {
+	GameInterface
+	fmt.Stringer
+	// Turn returns the mark of the player about to move
+	Turn() string
+	// Computer returns the computer's mark, or an empty string in a local game
+	Computer() string
+	ChooseSides(humanMark string, humanFirst bool) error
+	IsComputerTurn() bool
+	GetComputerMove() (rowIndex, columnIndex int)
+	SuggestMove(difficulty Difficulty) (rowIndex, columnIndex int)
+	MakeMove(rowIndex, columnIndex int) error
+	PositionName(rowIndex, columnIndex int) string
+	GetWinner() string
+	IsBoardFull() bool
+}
+
+const (
+	// MinSize is the smallest board size
+	MinSize = 3
+	// MaxSize is the largest board size, limited by the column letters a-z
+	MaxSize = 26
+	// maxNumberedCells is the most squares a board can have while they are
+	// still numbered 1-9 instead of named by coordinates
+	maxNumberedCells = 9
+)
+
+// Board represents a square Tic-tac-toe game board, indexed by row and then
+// column. Empty squares are represented by empty strings,
 // and played squares contain either "X" or "O".
-type Board [3][3]string
+type Board [][]string
+
+// NewBoard creates an empty board with size rows and columns.
+func NewBoard(size int) Board {
+	board := make(Board, size)
+	for rowIndex := range board {
+		board[rowIndex] = make([]string, size)
+	}
+	return board
+}
+
+// clone returns a copy of the board that can be changed independently.
+func (b Board) clone() Board {
+	board := make(Board, len(b))
+	for rowIndex, row := range b {
+		board[rowIndex] = append([]string(nil), row...)
+	}
+	return board
+}
 
 // Game represents the current state of a Tic-tac-toe game.
 type Game struct {
-	board         Board    // The game board storing player moves ("X" or "O", or empty for unplayed)
-	CurrentPlayer string   // CurrentPlayer indicates whose turn it is ("X" or "O")
-	Mode          GameMode // Mode indicates if playing against computer or local player
-	ComputerMark  string   // ComputerMark stores which mark (X/O) the computer is using
+	board         Board      // The game board storing player moves ("X" or "O", or empty for unplayed)
+	CurrentPlayer string     // CurrentPlayer indicates whose turn it is ("X" or "O")
+	Mode          GameMode   // Mode indicates if playing against computer or local player
+	ComputerMark  string     // ComputerMark stores which mark (X/O) the computer is using
+	HumanMark     string     // HumanMark stores which mark (X/O) the human plays against the computer
+	Difficulty    Difficulty // Difficulty sets how well the computer plays
+	WinLength     int        // WinLength is how many marks in a row win, the board size if zero
+	Variant       Variant    // Variant sets the rules for winning and which marks players place
+	Takebacks     bool       // Takebacks lets players undo and redo their moves
+	nextMark      string     // The mark chosen with ChooseMark for the next move in Wild
+	history       []Move     // The moves made so far, in the order they were played
+	undone        [][]Move   // The turns taken back by Undo, most recent last, which Redo plays again
+	rng           *rand.Rand // Source of randomness for the computer opponent
+}
+
+// NewGame creates and initializes a new Tic-tac-toe game with an empty 3x3 board.
+// X plays first. Against the computer the human plays X, unless ChooseSides
+// is called before the first move. The computer opponent uses rng to vary its play.
+func NewGame(mode GameMode, rng *rand.Rand) *Game {
+	game, _ := NewSizedGame(mode, MinSize, MinSize, rng)
+	return game
 }
 
-// NewGame creates and initializes a new Tic-tac-toe game with an empty board.
-// Setting as X always plays first.
-func NewGame(mode GameMode) *Game {
+// NewSizedGame creates a game on a board with size rows and columns, won by
+// getting winLength marks in a row, such as 15x15 with 5 in a row.
+// Returns an error if the size is not between MinSize and MaxSize, or the
+// win length is not between 3 and the size.
+func NewSizedGame(mode GameMode, size, winLength int, rng *rand.Rand) (*Game, error) {
+	if size < MinSize || size > MaxSize {
+		return nil, fmt.Errorf("invalid board size %d: must be between %d and %d", size, MinSize, MaxSize)
+	}
+	if winLength < MinSize || winLength > size {
+		return nil, fmt.Errorf("invalid win length %d: must be between %d and the board size %d", winLength, MinSize, size)
+	}
+
 	game := &Game{
-		board:         Board{},
+		board:         NewBoard(size),
 		CurrentPlayer: "X",
 		Mode:          mode,
+		WinLength:     winLength,
+		Takebacks:     true,
+		rng:           rng,
 	}
 	if mode == ComputerGame {
-		// Computer always plays as O
+		game.HumanMark = "X"
 		game.ComputerMark = "O"
 	}
-	return game
+	return game, nil
+}
+
+// ChooseSides sets the mark the human plays against the computer and which
+// side makes the first move, whichever mark that is.
+// Returns an error if:
+// - The game is not against the computer
+// - The mark is not "X" or "O"
+// - A move has already been made
+func (g *Game) ChooseSides(humanMark string, humanFirst bool) error {
+	if g.Mode != ComputerGame {
+		return errors.New("sides can only be chosen against the computer")
+	}
+	if humanMark != "X" && humanMark != "O" {
+		return fmt.Errorf("invalid mark %q: must be X or O", humanMark)
+	}
+	if len(g.GetAvailablePositions()) != g.Size()*g.Size() {
+		return errors.New("sides can only be chosen before the first move")
+	}
+
+	g.HumanMark = humanMark
+	g.ComputerMark = switchPlayer(humanMark)
+	g.CurrentPlayer = g.ComputerMark
+	if humanFirst {
+		g.CurrentPlayer = humanMark
+	}
+	return nil
+}
+
+// Size returns the number of rows and columns on the board.
+func (g *Game) Size() int {
+	return len(g.board)
+}
+
+// Turn returns the mark of the player about to move.
+func (g *Game) Turn() string {
+	return g.CurrentPlayer
+}
+
+// Computer returns the computer's mark, or an empty string in a local game.
+func (g *Game) Computer() string {
+	return g.ComputerMark
+}
+
+// winLength returns how many marks in a row win the game.
+func (g *Game) winLength() int {
+	if g.WinLength <= 0 {
+		return g.Size()
+	}
+	return g.WinLength
 }
 
-// MakeMove attempts to place the current player's mark at the specified position.
+// MakeMove attempts to place the current player's mark at the specified position,
+// or in Wild the mark they chose, and adds the move to the game's history. Moves taken back by Undo can no
+// longer be redone.
 // The position is specified using zero-based indices for row and column.
 // Returns an error if:
-// - The position is out of bounds (not between 0 and 2)
+// - The position is out of bounds (not between 0 and the board size minus one)
 // - The position is already occupied by a player's mark
 func (g *Game) MakeMove(rowIndex, columnIndex int) error {
-	if rowIndex < 0 || rowIndex > 2 || columnIndex < 0 || columnIndex > 2 {
-		return errors.New("invalid position: must be between 0 and 2")
+	if err := g.CheckMove(rowIndex, columnIndex); err != nil {
+		return err
+	}
+	g.play(Move{Player: g.CurrentPlayer, Mark: g.markToPlace(), RowIndex: rowIndex, ColumnIndex: columnIndex})
+	g.nextMark = ""
+	g.undone = nil
+	return nil
+}
+
+// play places a move on the board, adds it to the history and passes the
+// turn to the other player.
+func (g *Game) play(move Move) {
+	g.board[move.RowIndex][move.ColumnIndex] = move.Mark
+	g.history = append(g.history, move)
+	g.CurrentPlayer = switchPlayer(move.Player)
+}
+
+// CheckMove returns the error MakeMove would give for a move to the
+// specified position, or nil if the move can be played.
+func (g *Game) CheckMove(rowIndex, columnIndex int) error {
+	last := g.Size() - 1
+	if rowIndex < 0 || rowIndex > last || columnIndex < 0 || columnIndex > last {
+		return fmt.Errorf("invalid position: must be between 0 and %d", last)
 	}
 	if g.board[rowIndex][columnIndex] != "" {
 		return errors.New("position already taken")
 	}
-	g.board[rowIndex][columnIndex] = g.CurrentPlayer
-	g.CurrentPlayer = switchPlayer(g.CurrentPlayer)
 	return nil
 }
 
-// GetWinner checks if there is a winner by examining all possible winning combinations:
-// - Three rows
-// - Three columns
-// - Two diagonals
+// lineDirections are the row and column steps along a row, a column and the
+// two diagonals.
+var lineDirections = [4][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}}
+
+// GetWinner checks if there is a winner by looking for WinLength matching
+// marks in a row along every row, column and diagonal of the board.
+// In the standard game the player with the line wins. In Misère the player
+// who completed it loses, and in Wild the player who completed it wins,
+// whichever mark it is made of; either way the game ends as soon as the line
+// is made, so the player who made it is the one who moved last.
 // Returns the winning player's mark ("X" or "O"), or an empty string if there's no winner.
 func (g *Game) GetWinner() string {
-	// Check rows for a winner
-	for rowIndex := 0; rowIndex < 3; rowIndex++ {
-		if g.board[rowIndex][0] != "" &&
-			g.board[rowIndex][0] == g.board[rowIndex][1] &&
-			g.board[rowIndex][1] == g.board[rowIndex][2] {
-			return g.board[rowIndex][0]
-		}
+	line := g.board.Winner(g.winLength())
+	if line == "" {
+		return ""
+	}
+	switch g.Variant {
+	case Misere:
+		return g.CurrentPlayer
+	case Wild:
+		return switchPlayer(g.CurrentPlayer)
+	default:
+		return line
 	}
+}
+
+// completesLine reports whether the mark at the given square is part of a
+// line of WinLength matching marks.
+func (g *Game) completesLine(rowIndex, columnIndex int) bool {
+	return g.board.completesLine(rowIndex, columnIndex, g.winLength())
+}
 
-	// Check columns for a winner
-	for columnIndex := 0; columnIndex < 3; columnIndex++ {
-		if g.board[0][columnIndex] != "" &&
-			g.board[0][columnIndex] == g.board[1][columnIndex] &&
-			g.board[1][columnIndex] == g.board[2][columnIndex] {
-			return g.board[0][columnIndex]
+// onBoard reports whether the row and column indices are inside the board.
+func (g *Game) onBoard(rowIndex, columnIndex int) bool {
+	return g.board.onBoard(rowIndex, columnIndex)
+}
+
+// IsBoardFull determines if all positions on the board have been played.
+// Returns true if no empty positions remain, false otherwise.
+func (g *Game) IsBoardFull() bool {
+	return g.board.IsFull()
+}
+
+// Winner returns the mark that has winLength in a row along a row, column or
+// diagonal of the board, or an empty string if neither player has.
+func (b Board) Winner(winLength int) string {
+	for rowIndex := range b {
+		for columnIndex, mark := range b[rowIndex] {
+			if mark != "" && b.completesLine(rowIndex, columnIndex, winLength) {
+				return mark
+			}
 		}
 	}
+	return ""
+}
 
-	// Check main diagonal (top-left to bottom-right)
-	if g.board[0][0] != "" &&
-		g.board[0][0] == g.board[1][1] &&
-		g.board[1][1] == g.board[2][2] {
-		return g.board[0][0]
+// completesLine reports whether the mark at the given square is part of a
+// line of at least winLength matching marks.
+func (b Board) completesLine(rowIndex, columnIndex, winLength int) bool {
+	mark := b[rowIndex][columnIndex]
+	if mark == "" {
+		return false
 	}
-
-	// Check secondary diagonal (top-right to bottom-left)
-	if g.board[0][2] != "" &&
-		g.board[0][2] == g.board[1][1] &&
-		g.board[1][1] == g.board[2][0] {
-		return g.board[0][2]
+	for _, direction := range lineDirections {
+		count := 1 + b.countFrom(rowIndex, columnIndex, direction[0], direction[1], mark) +
+			b.countFrom(rowIndex, columnIndex, -direction[0], -direction[1], mark)
+		if count >= winLength {
+			return true
+		}
 	}
+	return false
+}
 
-	return ""
+// countFrom counts the marks matching mark in a row after the given square,
+// stepping by rowStep and columnStep.
+func (b Board) countFrom(rowIndex, columnIndex, rowStep, columnStep int, mark string) int {
+	count := 0
+	for {
+		rowIndex += rowStep
+		columnIndex += columnStep
+		if !b.onBoard(rowIndex, columnIndex) || b[rowIndex][columnIndex] != mark {
+			return count
+		}
+		count++
+	}
 }
 
-// IsBoardFull determines if all positions on the board have been played.
-// Returns true if no empty positions remain, false otherwise.
-func (g *Game) IsBoardFull() bool {
+// onBoard reports whether the row and column indices are inside the board.
+func (b Board) onBoard(rowIndex, columnIndex int) bool {
+	return rowIndex >= 0 && rowIndex < len(b) && columnIndex >= 0 && columnIndex < len(b)
+}
 
-	for rowIndex := 0; rowIndex < 3; rowIndex++ {
-		for columnIndex := 0; columnIndex < 3; columnIndex++ {
-			if g.board[rowIndex][columnIndex] == "" {
+// IsFull reports whether every square on the board has been played.
+func (b Board) IsFull() bool {
+	for _, row := range b {
+		for _, mark := range row {
+			if mark == "" {
 				return false
 			}
 		}
@@ -138,10 +401,15 @@ func (g *Game) IsBoardFull() bool {
 }
 
 // String returns a formatted string representation of the current board state.
-// Empty squares are shown as numbers 1-9 for position selection, making it
-// easier for players to choose their moves. Played squares show the player's mark
-// in their respective colors (blue for X, yellow for O).
+// On a 3x3 board, empty squares are shown as numbers 1-9 for position
+// selection, making it easier for players to choose their moves. Larger boards
+// are labelled with column letters and row numbers instead. Played squares
+// show the player's mark in their respective colors.
 func (g *Game) String() string {
+	if g.UsesCoordinates() {
+		return g.coordinateString()
+	}
+
 	result := "\n"
 	position := 1
 	for rowIndex := 0; rowIndex < 3; rowIndex++ {
@@ -155,12 +423,7 @@ func (g *Game) String() string {
 			if g.board[rowIndex][columnIndex] == "" {
 				result += fmt.Sprintf("%d", position)
 			} else {
-				mark := g.board[rowIndex][columnIndex]
-				if mark == "X" {
-					result += xStyle.Render("X")
-				} else {
-					result += oStyle.Render("O")
-				}
+				result += RenderMark(g.board[rowIndex][columnIndex])
 			}
 
 			// Add column separators except for the last column
@@ -179,6 +442,30 @@ func (g *Game) String() string {
 	return result
 }
 
+// coordinateString draws a board whose squares are named by coordinates,
+// with the column letters above and the row numbers to the left.
+func (g *Game) coordinateString() string {
+	var sb strings.Builder
+	sb.WriteString("\n   ")
+	for columnIndex := range g.Size() {
+		sb.WriteString(" " + columnName(columnIndex))
+	}
+	sb.WriteString("\n")
+
+	for rowIndex, row := range g.board {
+		fmt.Fprintf(&sb, "%2d ", rowIndex+1)
+		for _, mark := range row {
+			if mark == "" {
+				sb.WriteString(" .")
+			} else {
+				sb.WriteString(" " + RenderMark(mark))
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
 // switchPlayer determines the next player's turn.
 // Following traditional Tic-tac-toe rules, players alternate between X and O.
 func switchPlayer(currentPlayer string) string {
@@ -202,14 +489,75 @@ func positionToRowCol(position int) (rowIndex, columnIndex int) {
 	return position / 3, position % 3
 }
 
-// GetAvailablePositions returns a slice of strings representing unoccupied positions.
-// The positions are numbered 1-9 (one-based) to match the display format.
+// UsesCoordinates reports whether squares are named by coordinates such as
+// "c7", which happens once the board has more than nine squares. Smaller
+// boards number their squares 1-9.
+func (g *Game) UsesCoordinates() bool {
+	return g.Size()*g.Size() > maxNumberedCells
+}
+
+// columnName returns the letter naming a zero-based column.
+func columnName(columnIndex int) string {
+	return string(rune('a' + columnIndex))
+}
+
+// PositionName returns the name of a square: its number on a 3x3 board, or
+// its column letter and row number, such as "c7", on a larger one.
+func (g *Game) PositionName(rowIndex, columnIndex int) string {
+	if !g.UsesCoordinates() {
+		return strconv.Itoa(rowIndex*3 + columnIndex + 1)
+	}
+	return coordinateName(rowIndex, columnIndex)
+}
+
+// coordinateName names a square by its column letter and row number.
+func coordinateName(rowIndex, columnIndex int) string {
+	return columnName(columnIndex) + strconv.Itoa(rowIndex+1)
+}
+
+// ParsePosition converts a square's name, as returned by PositionName, to
+// zero-based row and column indices. Coordinates are not case-sensitive.
+func (g *Game) ParsePosition(name string) (rowIndex, columnIndex int, err error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !g.UsesCoordinates() {
+		position, err := strconv.Atoi(name)
+		if err != nil {
+			return -1, -1, fmt.Errorf("invalid position %q: must be a number from 1 to 9", name)
+		}
+		rowIndex, columnIndex = positionToRowCol(position)
+		if rowIndex < 0 {
+			return -1, -1, fmt.Errorf("invalid position %q: must be a number from 1 to 9", name)
+		}
+		return rowIndex, columnIndex, nil
+	}
+	return parseCoordinate(name, g.Size())
+}
+
+// parseCoordinate converts a lower-case square name such as "c7" on a board
+// with size rows and columns to zero-based row and column indices.
+func parseCoordinate(name string, size int) (rowIndex, columnIndex int, err error) {
+	invalid := fmt.Errorf("invalid square %q: use a column from a to %s and a row from 1 to %d, such as b2", name, columnName(size-1), size)
+	if len(name) < 2 {
+		return -1, -1, invalid
+	}
+	columnIndex = int(name[0] - 'a')
+	row, err := strconv.Atoi(name[1:])
+	if err != nil || row < 1 || row > size || columnIndex < 0 || columnIndex >= size {
+		return -1, -1, invalid
+	}
+	return row - 1, columnIndex, nil
+}
+
+// GetAvailablePositions returns a slice of strings naming the unoccupied
+// squares, row by row. On a 3x3 board the positions are numbered 1-9
+// (one-based) to match the display format; larger boards use coordinates.
 func (g *Game) GetAvailablePositions() []string {
 	var availablePositions []string
-	for position := 1; position <= 9; position++ {
-		rowIndex, columnIndex := positionToRowCol(position)
-		if g.board[rowIndex][columnIndex] == "" {
-			availablePositions = append(availablePositions, fmt.Sprintf("%d", position))
+	for rowIndex, row := range g.board {
+		for columnIndex, mark := range row {
+			if mark == "" {
+				availablePositions = append(availablePositions, g.PositionName(rowIndex, columnIndex))
+			}
 		}
 	}
 	return availablePositions
@@ -217,21 +565,57 @@ func (g *Game) GetAvailablePositions() []string {
 
 // GetPlayerMove prompts the user to select a valid move and returns the chosen
 // row and column indices. It uses the provided Prompter interface to get user input.
+// On boards named by coordinates the square is typed in, and asked for again
+// until it names a free square. In Wild the player then picks the mark to
+// place.
+// If the game is Rewindable, the player may also choose Undo or Redo when
+// they are possible, and GetPlayerMove returns ErrUndo or ErrRedo.
 // Returns an error if:
 // - No valid moves are available (board is full)
 // - User input is invalid
 // - Selected position is invalid
 func GetPlayerMove(prompter Prompter, game GameInterface) (rowIndex, columnIndex int, err error) {
+	rowIndex, columnIndex, err = getSquare(prompter, game)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	if chooser, ok := game.(markChooser); ok && chooser.ChoosesMark() {
+		marks := []string{"X", "O"}
+		markIndex, err := prompter.Select("Place which mark?", "X", marks)
+		if err != nil {
+			return -1, -1, err
+		}
+		if markIndex < 0 || markIndex >= len(marks) {
+			return -1, -1, fmt.Errorf("invalid mark selection: %d", markIndex)
+		}
+		if err := chooser.ChooseMark(marks[markIndex]); err != nil {
+			return -1, -1, err
+		}
+	}
+	return rowIndex, columnIndex, nil
+}
+
+// getSquare asks the player for the square of their move, or for a takeback.
+func getSquare(prompter Prompter, game GameInterface) (rowIndex, columnIndex int, err error) {
 	availablePositions := game.GetAvailablePositions()
 	if len(availablePositions) == 0 {
 		return -1, -1, errors.New("no available moves")
 	}
+	takebacks := takebackOptions(game)
+
+	if coordinates, ok := game.(coordinateGame); ok && coordinates.UsesCoordinates() {
+		return getCoordinateMove(prompter, coordinates, availablePositions, takebacks)
+	}
 
-	posIndex, err := prompter.Select("Select position (1-9):", "1", availablePositions)
+	posIndex, err := prompter.Select("Select position (1-9):", "1", append(availablePositions, takebacks...))
 	if err != nil {
 		return -1, -1, err
 	}
 
+	if posIndex >= len(availablePositions) && posIndex < len(availablePositions)+len(takebacks) {
+		return -1, -1, takebackError(takebacks[posIndex-len(availablePositions)])
+	}
 	if posIndex < 0 || posIndex >= len(availablePositions) {
 		return -1, -1, fmt.Errorf("invalid position selection: %d", posIndex)
 	}
@@ -250,80 +634,93 @@ func GetPlayerMove(prompter Prompter, game GameInterface) (rowIndex, columnIndex
 	return rowIndex, columnIndex, nil
 }
 
-// IsComputerTurn returns true if it's the computer's turn in a computer game.
-// This will only return true if the game mode is ComputerGame and the current
-// player matches the computer's mark.
-func (g *Game) IsComputerTurn() bool {
-	return g.Mode == ComputerGame && g.CurrentPlayer == g.ComputerMark
-}
-
-// GetComputerMove determines the computer's next move using a simple strategy:
-// 1. Win if possible
-// 2. Block opponent's winning move if possible
-// 3. Take center if available
-// 4. Take a random corner if available
-// 5. Take any available space
-// Returns row and column indices for the chosen move.
-func (g *Game) GetComputerMove() (rowIndex, columnIndex int) {
-	// Try to win if possible
-	if move := g.findWinningMove(g.ComputerMark); move != nil {
-		return move[0], move[1]
+// takebackOptions lists the Undo and Redo options the game allows now.
+func takebackOptions(game GameInterface) []string {
+	rewindable, ok := game.(Rewindable)
+	if !ok {
+		return nil
 	}
-
-	// Block opponent's winning move if possible
-	playerMark := "X" // Player is always X
-	if move := g.findWinningMove(playerMark); move != nil {
-		return move[0], move[1]
+	var options []string
+	if rewindable.CanUndo() {
+		options = append(options, undoOption)
 	}
-
-	// Take center if available
-	if g.board[1][1] == "" {
-		return 1, 1
+	if rewindable.CanRedo() {
+		options = append(options, redoOption)
 	}
+	return options
+}
 
-	// Take a corner if available
-	corners := [][2]int{{0, 0}, {0, 2}, {2, 0}, {2, 2}}
-	rand.Shuffle(len(corners), func(i, j int) {
-		corners[i], corners[j] = corners[j], corners[i]
-	})
-	for _, corner := range corners {
-		if g.board[corner[0]][corner[1]] == "" {
-			return corner[0], corner[1]
-		}
+// takebackError returns the error GetPlayerMove reports for a takeback option.
+func takebackError(option string) error {
+	if option == undoOption {
+		return ErrUndo
 	}
+	return ErrRedo
+}
 
-	// Take any available space
-	for rowIndex := 0; rowIndex < 3; rowIndex++ {
-		for columnIndex := 0; columnIndex < 3; columnIndex++ {
-			if g.board[rowIndex][columnIndex] == "" {
-				return rowIndex, columnIndex
+// getCoordinateMove asks for a square by its coordinates until the player
+// names one of the available positions, or one of the takeback options. A
+// square that cannot be played is explained in the next prompt.
+func getCoordinateMove(prompter Prompter, game coordinateGame, availablePositions, takebacks []string) (rowIndex, columnIndex int, err error) {
+	prompt := "Enter a square (such as " + availablePositions[0] + "):"
+	if len(takebacks) > 0 {
+		prompt = fmt.Sprintf("Enter a square (such as %s) or %s:", availablePositions[0], strings.ToLower(strings.Join(takebacks, " or ")))
+	}
+	for {
+		answer, err := prompter.Input(prompt, "")
+		if err != nil {
+			return -1, -1, err
+		}
+		for _, option := range takebacks {
+			if strings.EqualFold(strings.TrimSpace(answer), option) {
+				return -1, -1, takebackError(option)
 			}
 		}
+
+		rowIndex, columnIndex, err := game.ParsePosition(answer)
+		if err != nil {
+			prompt = err.Error() + ". Enter a square:"
+			continue
+		}
+		if err := game.CheckMove(rowIndex, columnIndex); err != nil {
+			prompt = fmt.Sprintf("%s: %v. Enter a square:", game.PositionName(rowIndex, columnIndex), err)
+			continue
+		}
+		return rowIndex, columnIndex, nil
 	}
+}
 
-	return -1, -1
+// ChoosesMark reports whether players choose which mark to place on each
+// move, as they do in Wild.
+func (g *Game) ChoosesMark() bool {
+	return g.Variant == Wild
 }
 
-// findWinningMove checks if there's a winning move available for the given mark.
-// It simulates placing the mark in each empty position and checks if it results in a win.
-// Returns the [row, column] indices of the winning move, or nil if no winning move exists.
-func (g *Game) findWinningMove(mark string) []int {
-	// Try each empty position
-	for rowIndex := 0; rowIndex < 3; rowIndex++ {
-		for columnIndex := 0; columnIndex < 3; columnIndex++ {
-			if g.board[rowIndex][columnIndex] == "" {
-				// Try the move
-				g.board[rowIndex][columnIndex] = mark
-				// Check if it's a winning move
-				if g.GetWinner() == mark {
-					// Undo the move and return the position
-					g.board[rowIndex][columnIndex] = ""
-					return []int{rowIndex, columnIndex}
-				}
-				// Undo the move
-				g.board[rowIndex][columnIndex] = ""
-			}
-		}
+// ChooseMark sets the mark the next move places in Wild.
+// Returns an error if the game is not Wild or the mark is not "X" or "O".
+func (g *Game) ChooseMark(mark string) error {
+	if !g.ChoosesMark() {
+		return errors.New("marks can only be chosen in Wild")
+	}
+	if mark != "X" && mark != "O" {
+		return fmt.Errorf("invalid mark %q: must be X or O", mark)
 	}
+	g.nextMark = mark
 	return nil
 }
+
+// markToPlace returns the mark the next move places: the one chosen with
+// ChooseMark in Wild, otherwise the current player's own mark.
+func (g *Game) markToPlace() string {
+	if g.ChoosesMark() && g.nextMark != "" {
+		return g.nextMark
+	}
+	return g.CurrentPlayer
+}
+
+// IsComputerTurn returns true if it's the computer's turn in a computer game.
+// This will only return true if the game mode is ComputerGame and the current
+// player matches the computer's mark.
+func (g *Game) IsComputerTurn() bool {
+	return g.Mode == ComputerGame && g.CurrentPlayer == g.ComputerMark
+}
@@ -2,16 +2,24 @@ package tictactoe
 
 import (
 	"fmt"
+	"math/rand"
 	"strings"
 	"testing"
 )
 
+// newTestRand returns a deterministic random source for tests.
+func newTestRand() *rand.Rand {
+	return rand.New(rand.NewSource(1))
+}
+
 // mockPrompter implements the Prompter interface for tictactoe game testing.
 // It provides predefined select responses and can be configured to return errors.
 type mockPrompter struct {
-	selectAnswers []int // Predefined responses for Select calls
-	selectIndex   int   // Current index in selectAnswers
-	selectError   error // Error to be returned by Select
+	selectAnswers []int    // Predefined responses for Select calls
+	selectIndex   int      // Current index in selectAnswers
+	selectError   error    // Error to be returned by Select
+	inputAnswers  []string // Predefined responses for Input calls
+	inputIndex    int      // Current index in inputAnswers
 }
 
 // Select implements the Prompter interface by returning either the configured error
@@ -20,7 +28,7 @@ func (m *mockPrompter) Select(prompt string, defaultValue string, options []stri
 	if m.selectError != nil {
 		return 0, m.selectError
 	}
-	if len(m.selectAnswers) == 0 {
+	if m.selectIndex >= len(m.selectAnswers) {
 		return 0, fmt.Errorf("no answers configured")
 	}
 	answer := m.selectAnswers[m.selectIndex]
@@ -28,6 +36,17 @@ func (m *mockPrompter) Select(prompt string, defaultValue string, options []stri
 	return answer, nil
 }
 
+// Input implements the Prompter interface by returning the next answer from
+// inputAnswers, or an error once they run out.
+func (m *mockPrompter) Input(prompt string, defaultValue string) (string, error) {
+	if m.inputIndex >= len(m.inputAnswers) {
+		return "", fmt.Errorf("no answers configured")
+	}
+	answer := m.inputAnswers[m.inputIndex]
+	m.inputIndex++
+	return answer, nil
+}
+
 // mockGame implements GameInterface for testing purposes.
 // It allows controlling the available positions returned for tests.
 type mockGame struct {
@@ -46,7 +65,7 @@ func (m *mockGame) GetAvailablePositions() []string {
 // This is used to setup test scenarios with specific board states.
 // It fails the test if any of the moves are invalid.
 func setupGameWithMoves(moves [][2]int, t *testing.T) *Game {
-	game := NewGame(LocalGame)
+	game := NewGame(LocalGame, newTestRand())
 	for _, move := range moves {
 		if err := game.MakeMove(move[0], move[1]); err != nil {
 			// In test setup we expect all moves to be valid
@@ -85,6 +104,7 @@ func TestNewGame(t *testing.T) {
 		wantPlayer     string
 		wantMode       GameMode
 		wantCompMark   string
+		wantHumanMark  string
 		expectComputer bool
 	}{
 		{
@@ -101,13 +121,14 @@ func TestNewGame(t *testing.T) {
 			wantPlayer:     "X",
 			wantMode:       ComputerGame,
 			wantCompMark:   "O",
+			wantHumanMark:  "X",
 			expectComputer: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			game := NewGame(tt.mode)
+			game := NewGame(tt.mode, newTestRand())
 
 			if game.CurrentPlayer != tt.wantPlayer {
 				t.Errorf("NewGame() CurrentPlayer = %v, want %v", game.CurrentPlayer, tt.wantPlayer)
@@ -121,6 +142,10 @@ func TestNewGame(t *testing.T) {
 				t.Errorf("NewGame() ComputerMark = %v, want %v", game.ComputerMark, tt.wantCompMark)
 			}
 
+			if game.HumanMark != tt.wantHumanMark {
+				t.Errorf("NewGame() HumanMark = %v, want %v", game.HumanMark, tt.wantHumanMark)
+			}
+
 			// Verify board is empty
 			for i := 0; i < 3; i++ {
 				for j := 0; j < 3; j++ {
@@ -133,6 +158,59 @@ func TestNewGame(t *testing.T) {
 	}
 }
 
+func TestChooseSides(t *testing.T) {
+	tests := []struct {
+		name         string
+		humanMark    string
+		humanFirst   bool
+		wantComputer string
+		wantCurrent  string
+	}{
+		{name: "human X first", humanMark: "X", humanFirst: true, wantComputer: "O", wantCurrent: "X"},
+		{name: "human X second", humanMark: "X", humanFirst: false, wantComputer: "O", wantCurrent: "O"},
+		{name: "human O first", humanMark: "O", humanFirst: true, wantComputer: "X", wantCurrent: "O"},
+		{name: "human O second", humanMark: "O", humanFirst: false, wantComputer: "X", wantCurrent: "X"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewGame(ComputerGame, newTestRand())
+			if err := game.ChooseSides(tt.humanMark, tt.humanFirst); err != nil {
+				t.Fatalf("ChooseSides() unexpected error: %v", err)
+			}
+			if game.HumanMark != tt.humanMark || game.ComputerMark != tt.wantComputer {
+				t.Errorf("ChooseSides() marks = human %s, computer %s, want %s and %s",
+					game.HumanMark, game.ComputerMark, tt.humanMark, tt.wantComputer)
+			}
+			if game.CurrentPlayer != tt.wantCurrent {
+				t.Errorf("ChooseSides() CurrentPlayer = %s, want %s", game.CurrentPlayer, tt.wantCurrent)
+			}
+			if game.IsComputerTurn() == tt.humanFirst {
+				t.Errorf("IsComputerTurn() = %v with humanFirst %v", game.IsComputerTurn(), tt.humanFirst)
+			}
+		})
+	}
+}
+
+func TestChooseSidesErrors(t *testing.T) {
+	local := NewGame(LocalGame, newTestRand())
+	if err := local.ChooseSides("X", true); err == nil {
+		t.Error("ChooseSides() in a local game did not return an error")
+	}
+
+	game := NewGame(ComputerGame, newTestRand())
+	if err := game.ChooseSides("Z", true); err == nil {
+		t.Error("ChooseSides() with an invalid mark did not return an error")
+	}
+
+	if err := game.MakeMove(0, 0); err != nil {
+		t.Fatalf("MakeMove() unexpected error: %v", err)
+	}
+	if err := game.ChooseSides("O", true); err == nil {
+		t.Error("ChooseSides() after the first move did not return an error")
+	}
+}
+
 func TestMakeMove(t *testing.T) {
 	tests := []gameTestCase{
 		{
@@ -163,7 +241,7 @@ func TestMakeMove(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			game := NewGame(LocalGame)
+			game := NewGame(LocalGame, newTestRand())
 			var lastErr error
 
 			for _, move := range tt.moves {
@@ -253,7 +331,7 @@ func TestGetWinner(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			game := NewGame(LocalGame)
+			game := NewGame(LocalGame, newTestRand())
 			for _, move := range tt.moves {
 				if err := game.MakeMove(move[0], move[1]); err != nil {
 					t.Fatalf("Failed to make move %v: %v", move, err)
@@ -301,7 +379,7 @@ func TestIsBoardFull(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			game := NewGame(LocalGame)
+			game := NewGame(LocalGame, newTestRand())
 			for _, move := range tt.moves {
 				err := game.MakeMove(move[0], move[1])
 				if err != nil {
@@ -342,7 +420,7 @@ func TestGetPlayerMove(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			game := NewGame(LocalGame)
+			game := NewGame(LocalGame, newTestRand())
 			for _, move := range tt.boardState {
 				if err := game.MakeMove(move[0], move[1]); err != nil {
 					t.Fatalf("Failed to make move %v: %v", move, err)
@@ -386,13 +464,13 @@ func TestGetPlayerMoveErrors(t *testing.T) {
 		},
 		{
 			name:          "invalid position selection",
-			game:          NewGame(LocalGame),
+			game:          NewGame(LocalGame, newTestRand()),
 			selectAnswers: []int{9},
 			expectedError: "invalid position",
 		},
 		{
 			name:          "prompter error is propagated",
-			game:          NewGame(LocalGame),
+			game:          NewGame(LocalGame, newTestRand()),
 			selectError:   fmt.Errorf("prompter error"),
 			expectedError: "prompter error",
 		},
@@ -404,7 +482,7 @@ func TestGetPlayerMoveErrors(t *testing.T) {
 		},
 		{
 			name:          "negative position selection",
-			game:          NewGame(LocalGame),
+			game:          NewGame(LocalGame, newTestRand()),
 			selectAnswers: []int{-1},
 			expectedError: "invalid position",
 		},
@@ -864,7 +942,7 @@ func TestGetComputerMove(t *testing.T) {
 			},
 		},
 		{
-			name: "computer takes first available space when all other options exhausted",
+			name: "computer takes a free square when every move scores the same",
 			board: Board{
 				{"X", "", "O"},
 				{"X", "O", "X"},
@@ -872,7 +950,8 @@ func TestGetComputerMove(t *testing.T) {
 			},
 			computerMark: "O",
 			allowedPos: map[int]bool{
-				1: true, // Position (0,1) should be taken as it's the first available space
+				1: true, // Position (0,1)
+				8: true, // Position (2,2)
 			},
 		},
 		{
@@ -949,6 +1028,7 @@ func TestGetComputerMove(t *testing.T) {
 			game := &Game{
 				board:        tt.board,
 				ComputerMark: tt.computerMark,
+				rng:          newTestRand(),
 			}
 
 			row, col := game.GetComputerMove()
@@ -988,3 +1068,196 @@ func TestGetComputerMove(t *testing.T) {
 		})
 	}
 }
+
+func TestGetComputerMove_SameSeedSameMove(t *testing.T) {
+	board := Board{
+		{"", "", ""},
+		{"", "X", ""},
+		{"", "", ""},
+	}
+
+	for seed := int64(0); seed < 20; seed++ {
+		first := &Game{board: board, ComputerMark: "O", rng: rand.New(rand.NewSource(seed))}
+		second := &Game{board: board, ComputerMark: "O", rng: rand.New(rand.NewSource(seed))}
+
+		firstRow, firstCol := first.GetComputerMove()
+		secondRow, secondCol := second.GetComputerMove()
+		if firstRow != secondRow || firstCol != secondCol {
+			t.Errorf("seed %d chose different moves: (%d,%d) vs (%d,%d)",
+				seed, firstRow, firstCol, secondRow, secondCol)
+		}
+	}
+}
+
+func TestNewSizedGame(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      int
+		winLength int
+		wantErr   bool
+	}{
+		{name: "4x4 with four in a row", size: 4, winLength: 4},
+		{name: "15x15 with five in a row", size: 15, winLength: 5},
+		{name: "largest board", size: MaxSize, winLength: 5},
+		{name: "board too small", size: 2, winLength: 2, wantErr: true},
+		{name: "board too large", size: MaxSize + 1, winLength: 5, wantErr: true},
+		{name: "win longer than the board", size: 4, winLength: 5, wantErr: true},
+		{name: "win too short", size: 4, winLength: 2, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := NewSizedGame(LocalGame, tt.size, tt.winLength, newTestRand())
+			if tt.wantErr {
+				if err == nil {
+					t.Error("NewSizedGame() did not return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewSizedGame() unexpected error: %v", err)
+			}
+			if game.Size() != tt.size || len(game.GetAvailablePositions()) != tt.size*tt.size {
+				t.Errorf("NewSizedGame() board is %dx%d with %d free squares, want %dx%d and all free",
+					game.Size(), game.Size(), len(game.GetAvailablePositions()), tt.size, tt.size)
+			}
+		})
+	}
+}
+
+func TestGetWinner_LargeBoards(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      int
+		winLength int
+		xSquares  [][2]int
+		want      string
+	}{
+		{
+			name: "four in a row on 4x4", size: 4, winLength: 4,
+			xSquares: [][2]int{{2, 0}, {2, 1}, {2, 2}, {2, 3}},
+			want:     "X",
+		},
+		{
+			name: "three is not enough on 4x4 with four to win", size: 4, winLength: 4,
+			xSquares: [][2]int{{0, 0}, {1, 1}, {2, 2}},
+		},
+		{
+			name: "five on an anti-diagonal of 15x15", size: 15, winLength: 5,
+			xSquares: [][2]int{{3, 10}, {4, 9}, {5, 8}, {6, 7}, {7, 6}},
+			want:     "X",
+		},
+		{
+			name: "three in a row wins when three is enough", size: 7, winLength: 3,
+			xSquares: [][2]int{{6, 4}, {5, 4}, {4, 4}},
+			want:     "X",
+		},
+		{
+			name: "broken line does not win", size: 15, winLength: 5,
+			xSquares: [][2]int{{0, 0}, {0, 1}, {0, 2}, {0, 4}, {0, 5}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := NewSizedGame(LocalGame, tt.size, tt.winLength, newTestRand())
+			if err != nil {
+				t.Fatalf("NewSizedGame() unexpected error: %v", err)
+			}
+			for _, square := range tt.xSquares {
+				game.board[square[0]][square[1]] = "X"
+			}
+			if got := game.GetWinner(); got != tt.want {
+				t.Errorf("GetWinner() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPositionNames(t *testing.T) {
+	small := NewGame(LocalGame, newTestRand())
+	large, err := NewSizedGame(LocalGame, 15, 5, newTestRand())
+	if err != nil {
+		t.Fatalf("NewSizedGame() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		game       *Game
+		name       string
+		wantRow    int
+		wantColumn int
+	}{
+		{game: small, name: "5", wantRow: 1, wantColumn: 1},
+		{game: small, name: "9", wantRow: 2, wantColumn: 2},
+		{game: large, name: "c7", wantRow: 6, wantColumn: 2},
+		{game: large, name: "a1", wantRow: 0, wantColumn: 0},
+		{game: large, name: "o15", wantRow: 14, wantColumn: 14},
+	}
+	for _, tt := range tests {
+		rowIndex, columnIndex, err := tt.game.ParsePosition(tt.name)
+		if err != nil || rowIndex != tt.wantRow || columnIndex != tt.wantColumn {
+			t.Errorf("ParsePosition(%q) = (%d,%d,%v), want (%d,%d)", tt.name, rowIndex, columnIndex, err, tt.wantRow, tt.wantColumn)
+		}
+		if got := tt.game.PositionName(tt.wantRow, tt.wantColumn); got != tt.name {
+			t.Errorf("PositionName(%d, %d) = %q, want %q", tt.wantRow, tt.wantColumn, got, tt.name)
+		}
+	}
+
+	if rowIndex, columnIndex, err := large.ParsePosition(" C7 "); err != nil || rowIndex != 6 || columnIndex != 2 {
+		t.Errorf("ParsePosition() is case-sensitive: got (%d,%d,%v)", rowIndex, columnIndex, err)
+	}
+	for _, name := range []string{"p1", "a16", "a0", "7", "c", "", "cc"} {
+		if _, _, err := large.ParsePosition(name); err == nil {
+			t.Errorf("ParsePosition(%q) on 15x15 did not return an error", name)
+		}
+	}
+	for _, name := range []string{"0", "10", "a1"} {
+		if _, _, err := small.ParsePosition(name); err == nil {
+			t.Errorf("ParsePosition(%q) on 3x3 did not return an error", name)
+		}
+	}
+}
+
+func TestString_CoordinateBoard(t *testing.T) {
+	game, err := NewSizedGame(LocalGame, 4, 4, newTestRand())
+	if err != nil {
+		t.Fatalf("NewSizedGame() unexpected error: %v", err)
+	}
+	if err := game.MakeMove(1, 2); err != nil {
+		t.Fatalf("MakeMove() unexpected error: %v", err)
+	}
+
+	board := game.String()
+	for _, want := range []string{"a b c d", " 1 ", " 4 ", xStyle.Render("X")} {
+		if !strings.Contains(board, want) {
+			t.Errorf("String() = %q, want it to contain %q", board, want)
+		}
+	}
+	if strings.Contains(board, "---+") {
+		t.Errorf("String() drew the 3x3 grid for a 4x4 board: %q", board)
+	}
+}
+
+func TestGetPlayerMove_Coordinates(t *testing.T) {
+	game, err := NewSizedGame(LocalGame, 15, 5, newTestRand())
+	if err != nil {
+		t.Fatalf("NewSizedGame() unexpected error: %v", err)
+	}
+	if err := game.MakeMove(6, 2); err != nil {
+		t.Fatalf("MakeMove() unexpected error: %v", err)
+	}
+
+	// An unknown square and a taken one are asked for again
+	mockP := &mockPrompter{inputAnswers: []string{"z99", "c7", "D8"}}
+	rowIndex, columnIndex, err := GetPlayerMove(mockP, game)
+	if err != nil {
+		t.Fatalf("GetPlayerMove() unexpected error: %v", err)
+	}
+	if rowIndex != 7 || columnIndex != 3 {
+		t.Errorf("GetPlayerMove() = (%d,%d), want (7,3) for d8", rowIndex, columnIndex)
+	}
+
+	if _, _, err := GetPlayerMove(&mockPrompter{}, game); err == nil {
+		t.Error("GetPlayerMove() did not pass on the prompter error")
+	}
+}
@@ -0,0 +1,405 @@
+package tictactoe
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/chrisreddington/gh-game/internal/theme"
+)
+
+// ultimateSize is the number of rows and columns of small boards in ultimate
+// tic-tac-toe, and of squares on each small board.
+const ultimateSize = 3
+
+// ultimateDepth is how many moves ahead the computer searches in ultimate
+// tic-tac-toe when its difficulty does not limit the search further.
+const ultimateDepth = 3
+
+// UltimateGame represents a game of ultimate tic-tac-toe: a 3x3 grid of small
+// tic-tac-toe boards. Winning a small board claims its square on the big
+// board, and three claimed squares in a row win the game. The square a move
+// is played in sends the opponent to the small board in the same place; if
+// that board is already won or full, the opponent may play on any board.
+//
+// Squares are named by their column letter and row number on the whole 9x9
+// grid, from a1 in the top left to i9 in the bottom right.
+type UltimateGame struct {
+	boards        [ultimateSize][ultimateSize]Board // The small boards, by their row and column on the big board
+	meta          Board                             // The big board, holding the winner of each small board
+	active        [2]int                            // The small board the next move must be played on, or {-1, -1} for any
+	CurrentPlayer string                            // CurrentPlayer indicates whose turn it is ("X" or "O")
+	Mode          GameMode                          // Mode indicates if playing against computer or local player
+	ComputerMark  string                            // ComputerMark stores which mark (X/O) the computer is using
+	HumanMark     string                            // HumanMark stores which mark (X/O) the human plays against the computer
+	Difficulty    Difficulty                        // Difficulty sets how well the computer plays
+	rng           *rand.Rand                        // Source of randomness for the computer opponent
+}
+
+// NewUltimateGame creates a game of ultimate tic-tac-toe with every small
+// board empty. X plays first, anywhere on the grid. Against the computer the
+// human plays X, unless ChooseSides is called before the first move.
+func NewUltimateGame(mode GameMode, rng *rand.Rand) *UltimateGame {
+	game := &UltimateGame{
+		meta:          NewBoard(ultimateSize),
+		active:        [2]int{-1, -1},
+		CurrentPlayer: "X",
+		Mode:          mode,
+		rng:           rng,
+	}
+	for boardRow := range game.boards {
+		for boardColumn := range game.boards[boardRow] {
+			game.boards[boardRow][boardColumn] = NewBoard(ultimateSize)
+		}
+	}
+	if mode == ComputerGame {
+		game.HumanMark = "X"
+		game.ComputerMark = "O"
+	}
+	return game
+}
+
+// ChooseSides sets the mark the human plays against the computer and which
+// side makes the first move, whichever mark that is.
+// Returns an error if:
+// - The game is not against the computer
+// - The mark is not "X" or "O"
+// - A move has already been made
+func (g *UltimateGame) ChooseSides(humanMark string, humanFirst bool) error {
+	if g.Mode != ComputerGame {
+		return errors.New("sides can only be chosen against the computer")
+	}
+	if humanMark != "X" && humanMark != "O" {
+		return fmt.Errorf("invalid mark %q: must be X or O", humanMark)
+	}
+	if len(g.GetAvailablePositions()) != ultimateSize*ultimateSize*ultimateSize*ultimateSize {
+		return errors.New("sides can only be chosen before the first move")
+	}
+
+	g.HumanMark = humanMark
+	g.ComputerMark = switchPlayer(humanMark)
+	g.CurrentPlayer = g.ComputerMark
+	if humanFirst {
+		g.CurrentPlayer = humanMark
+	}
+	return nil
+}
+
+// Turn returns the mark of the player about to move.
+func (g *UltimateGame) Turn() string {
+	return g.CurrentPlayer
+}
+
+// Computer returns the computer's mark, or an empty string in a local game.
+func (g *UltimateGame) Computer() string {
+	return g.ComputerMark
+}
+
+// IsComputerTurn returns true if it's the computer's turn in a computer game.
+func (g *UltimateGame) IsComputerTurn() bool {
+	return g.Mode == ComputerGame && g.CurrentPlayer == g.ComputerMark
+}
+
+// decided reports whether the small board at the given place on the big
+// board can no longer be played, because it has been won or is full.
+func (g *UltimateGame) decided(boardRow, boardColumn int) bool {
+	return g.meta[boardRow][boardColumn] != "" || g.boards[boardRow][boardColumn].IsFull()
+}
+
+// ActiveBoard returns the row and column on the big board of the small board
+// the next move must be played on, or (-1, -1) if it may be played on any
+// small board that is still open.
+func (g *UltimateGame) ActiveBoard() (boardRow, boardColumn int) {
+	return g.active[0], g.active[1]
+}
+
+// CheckMove returns the error MakeMove would give for a move to the
+// specified square of the 9x9 grid, or nil if the move can be played.
+func (g *UltimateGame) CheckMove(rowIndex, columnIndex int) error {
+	last := ultimateSize*ultimateSize - 1
+	if rowIndex < 0 || rowIndex > last || columnIndex < 0 || columnIndex > last {
+		return fmt.Errorf("invalid position: must be between 0 and %d", last)
+	}
+	boardRow, boardColumn := rowIndex/ultimateSize, columnIndex/ultimateSize
+	if g.boards[boardRow][boardColumn][rowIndex%ultimateSize][columnIndex%ultimateSize] != "" {
+		return errors.New("position already taken")
+	}
+	if g.decided(boardRow, boardColumn) {
+		return errors.New("that small board has already been decided")
+	}
+	if g.active[0] >= 0 && (boardRow != g.active[0] || boardColumn != g.active[1]) {
+		return fmt.Errorf("you must play on the %s board", g.boardName(g.active[0], g.active[1]))
+	}
+	return nil
+}
+
+// MakeMove places the current player's mark on the specified square of the
+// 9x9 grid, claims the small board if the move wins it, and sends the
+// opponent to the matching small board.
+// Returns an error if the square is off the grid, already taken, or not on
+// the small board the player was sent to.
+func (g *UltimateGame) MakeMove(rowIndex, columnIndex int) error {
+	if err := g.CheckMove(rowIndex, columnIndex); err != nil {
+		return err
+	}
+	g.place(rowIndex, columnIndex, g.CurrentPlayer)
+	g.CurrentPlayer = switchPlayer(g.CurrentPlayer)
+	return nil
+}
+
+// place puts mark on a square of the 9x9 grid and updates the big board and
+// the active small board to match. It returns the active small board before
+// the move, which undo needs to take the move back.
+func (g *UltimateGame) place(rowIndex, columnIndex int, mark string) (previous [2]int) {
+	previous = g.active
+	boardRow, boardColumn := rowIndex/ultimateSize, columnIndex/ultimateSize
+	row, column := rowIndex%ultimateSize, columnIndex%ultimateSize
+
+	board := g.boards[boardRow][boardColumn]
+	board[row][column] = mark
+	if board.completesLine(row, column, ultimateSize) {
+		g.meta[boardRow][boardColumn] = mark
+	}
+
+	g.active = [2]int{row, column}
+	if g.decided(row, column) {
+		g.active = [2]int{-1, -1}
+	}
+	return previous
+}
+
+// undo takes back a move made by place. Moves are only played on small boards
+// that are still open, so the small board is unclaimed again afterwards.
+func (g *UltimateGame) undo(rowIndex, columnIndex int, previous [2]int) {
+	boardRow, boardColumn := rowIndex/ultimateSize, columnIndex/ultimateSize
+	g.boards[boardRow][boardColumn][rowIndex%ultimateSize][columnIndex%ultimateSize] = ""
+	g.meta[boardRow][boardColumn] = ""
+	g.active = previous
+}
+
+// GetWinner returns the mark that has claimed three small boards in a row on
+// the big board, or an empty string if neither player has.
+func (g *UltimateGame) GetWinner() string {
+	return g.meta.Winner(ultimateSize)
+}
+
+// IsBoardFull reports whether no moves are left, because every small board
+// has been won or filled.
+func (g *UltimateGame) IsBoardFull() bool {
+	return len(g.legalMoves()) == 0
+}
+
+// legalMoves lists the squares of the 9x9 grid the current player may play,
+// row by row.
+func (g *UltimateGame) legalMoves() [][2]int {
+	var moves [][2]int
+	for rowIndex := range ultimateSize * ultimateSize {
+		for columnIndex := range ultimateSize * ultimateSize {
+			if g.CheckMove(rowIndex, columnIndex) == nil {
+				moves = append(moves, [2]int{rowIndex, columnIndex})
+			}
+		}
+	}
+	return moves
+}
+
+// UsesCoordinates reports that squares are named by coordinates, since the
+// grid has 81 squares.
+func (g *UltimateGame) UsesCoordinates() bool {
+	return true
+}
+
+// PositionName returns the name of a square of the 9x9 grid, such as "e5".
+func (g *UltimateGame) PositionName(rowIndex, columnIndex int) string {
+	return coordinateName(rowIndex, columnIndex)
+}
+
+// ParsePosition converts a square's name, as returned by PositionName, to
+// zero-based row and column indices on the 9x9 grid.
+func (g *UltimateGame) ParsePosition(name string) (rowIndex, columnIndex int, err error) {
+	return parseCoordinate(strings.ToLower(strings.TrimSpace(name)), ultimateSize*ultimateSize)
+}
+
+// GetAvailablePositions returns the names of the squares the current player
+// may play, row by row.
+func (g *UltimateGame) GetAvailablePositions() []string {
+	var positions []string
+	for _, move := range g.legalMoves() {
+		positions = append(positions, g.PositionName(move[0], move[1]))
+	}
+	return positions
+}
+
+// boardName names a small board by the squares in its corners, such as
+// "d4-f6" for the center board.
+func (g *UltimateGame) boardName(boardRow, boardColumn int) string {
+	first := coordinateName(boardRow*ultimateSize, boardColumn*ultimateSize)
+	last := coordinateName(boardRow*ultimateSize+ultimateSize-1, boardColumn*ultimateSize+ultimateSize-1)
+	return first + "-" + last
+}
+
+// GetComputerMove chooses the computer's next move with the same alpha-beta
+// search as the standard game, cut off a few moves ahead. Positions are
+// scored by the lines each side can still complete on the small boards that
+// are open, and, ten times over, on the big board.
+// Returns row and column indices on the 9x9 grid, or (-1, -1) if no moves
+// are left.
+func (g *UltimateGame) GetComputerMove() (rowIndex, columnIndex int) {
+	return g.chooseMove(g.ComputerMark, g.Difficulty)
+}
+
+// SuggestMove chooses a move for the player about to move the way the
+// computer would at the given difficulty, whoever that player is.
+// Returns (-1, -1) if no moves are left.
+func (g *UltimateGame) SuggestMove(difficulty Difficulty) (rowIndex, columnIndex int) {
+	return g.chooseMove(g.CurrentPlayer, difficulty)
+}
+
+// chooseMove searches for the best move for mark at the given difficulty.
+func (g *UltimateGame) chooseMove(mark string, difficulty Difficulty) (rowIndex, columnIndex int) {
+	moves := g.legalMoves()
+	if len(moves) == 0 {
+		return -1, -1
+	}
+	g.rng.Shuffle(len(moves), func(i, j int) {
+		moves[i], moves[j] = moves[j], moves[i]
+	})
+
+	level := Levels[difficulty]
+	if level.MistakeRate > 0 && g.rng.Float64() < level.MistakeRate {
+		return moves[0][0], moves[0][1]
+	}
+
+	depth := level.Depth
+	if depth == 0 || depth > ultimateDepth {
+		depth = ultimateDepth
+	}
+
+	best := moves[0]
+	bestScore := -2 * winScore
+	for _, move := range moves {
+		score := g.scoreMove(move, mark, 1, depth, bestScore, 2*winScore)
+		if score > bestScore {
+			best, bestScore = move, score
+		}
+	}
+	return best[0], best[1]
+}
+
+// scoreMove plays mark at move, scores the result for the player who made
+// the move and takes the move back.
+func (g *UltimateGame) scoreMove(move [2]int, mark string, ply, depth, alpha, beta int) int {
+	previous := g.place(move[0], move[1], mark)
+	defer g.undo(move[0], move[1], previous)
+
+	if g.GetWinner() != "" {
+		return winScore - ply
+	}
+	return -g.negamax(switchPlayer(mark), ply+1, depth, -beta, -alpha)
+}
+
+// negamax scores the position for the player about to place mark, searching
+// up to depth moves ahead in total. Positions past the search depth are
+// scored by evaluate.
+func (g *UltimateGame) negamax(mark string, ply, depth, alpha, beta int) int {
+	moves := g.legalMoves()
+	if len(moves) == 0 {
+		return 0
+	}
+	if ply > depth {
+		return g.evaluate(mark)
+	}
+
+	best := -2 * winScore
+	for _, move := range moves {
+		score := g.scoreMove(move, mark, ply, depth, alpha, beta)
+		best = max(best, score)
+		alpha = max(alpha, score)
+		if alpha >= beta {
+			break
+		}
+	}
+	return best
+}
+
+// evaluate scores an unfinished position for the player about to place mark.
+func (g *UltimateGame) evaluate(mark string) int {
+	score := 10 * g.meta.lineScore(mark, ultimateSize)
+	for boardRow := range g.boards {
+		for boardColumn, board := range g.boards[boardRow] {
+			if !g.decided(boardRow, boardColumn) {
+				score += board.lineScore(mark, ultimateSize)
+			}
+		}
+	}
+	return score
+}
+
+// String returns the 9x9 grid with the small boards separated by lines and
+// labelled with column letters and row numbers. Empty squares that can be
+// played next are highlighted. Below the grid, the big board shows which
+// small boards each player has won, and a line says where to play next.
+func (g *UltimateGame) String() string {
+	var sb strings.Builder
+	sb.WriteString("\n   ")
+	for columnIndex := range ultimateSize * ultimateSize {
+		if columnIndex > 0 && columnIndex%ultimateSize == 0 {
+			sb.WriteString("  ")
+		}
+		sb.WriteString(" " + columnName(columnIndex))
+	}
+	sb.WriteString("\n")
+
+	separator := "   " + strings.Repeat("-", 2*ultimateSize+1)
+	separator += strings.Repeat("+"+strings.Repeat("-", 2*ultimateSize+1), ultimateSize-1) + "\n"
+
+	for rowIndex := range ultimateSize * ultimateSize {
+		if rowIndex > 0 && rowIndex%ultimateSize == 0 {
+			sb.WriteString(separator)
+		}
+		fmt.Fprintf(&sb, "%2d ", rowIndex+1)
+		for columnIndex := range ultimateSize * ultimateSize {
+			if columnIndex > 0 && columnIndex%ultimateSize == 0 {
+				sb.WriteString(" |")
+			}
+			boardRow, boardColumn := rowIndex/ultimateSize, columnIndex/ultimateSize
+			mark := g.boards[boardRow][boardColumn][rowIndex%ultimateSize][columnIndex%ultimateSize]
+			switch {
+			case mark != "":
+				sb.WriteString(" " + RenderMark(mark))
+			case g.CheckMove(rowIndex, columnIndex) == nil:
+				sb.WriteString(" " + theme.Highlight.Render("."))
+			default:
+				sb.WriteString(" " + theme.Muted.Render("."))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\nBoards won:\n")
+	for boardRow := range g.boards {
+		sb.WriteString(" ")
+		for boardColumn := range g.boards[boardRow] {
+			switch {
+			case g.meta[boardRow][boardColumn] != "":
+				sb.WriteString(" " + RenderMark(g.meta[boardRow][boardColumn]))
+			case g.decided(boardRow, boardColumn):
+				sb.WriteString(" " + theme.Muted.Render("-"))
+			default:
+				sb.WriteString(" " + theme.Muted.Render(strconv.Itoa(boardRow*ultimateSize+boardColumn+1)))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	if g.GetWinner() == "" && !g.IsBoardFull() {
+		if g.active[0] >= 0 {
+			fmt.Fprintf(&sb, "Play on the %s board\n", theme.Highlight.Render(g.boardName(g.active[0], g.active[1])))
+		} else {
+			sb.WriteString("Play on any open board\n")
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,224 @@
+package tictactoe
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// playUltimate makes each move, named by its square, failing the test if
+// any is rejected.
+func playUltimate(t *testing.T, game *UltimateGame, squares ...string) {
+	t.Helper()
+	for _, square := range squares {
+		rowIndex, columnIndex, err := game.ParsePosition(square)
+		if err != nil {
+			t.Fatalf("ParsePosition(%q) unexpected error: %v", square, err)
+		}
+		if err := game.MakeMove(rowIndex, columnIndex); err != nil {
+			t.Fatalf("MakeMove(%s) unexpected error: %v", square, err)
+		}
+	}
+}
+
+func TestNewUltimateGame(t *testing.T) {
+	game := NewUltimateGame(ComputerGame, newTestRand())
+	if game.CurrentPlayer != "X" || game.HumanMark != "X" || game.ComputerMark != "O" {
+		t.Errorf("NewUltimateGame() = current %s, human %s, computer %s, want X, X, O", game.CurrentPlayer, game.HumanMark, game.ComputerMark)
+	}
+	if got := len(game.GetAvailablePositions()); got != 81 {
+		t.Errorf("GetAvailablePositions() has %d squares, want all 81", got)
+	}
+	if boardRow, boardColumn := game.ActiveBoard(); boardRow != -1 || boardColumn != -1 {
+		t.Errorf("ActiveBoard() = (%d,%d), want (-1,-1) before the first move", boardRow, boardColumn)
+	}
+}
+
+func TestUltimateGame_MoveSendsOpponent(t *testing.T) {
+	game := NewUltimateGame(LocalGame, newTestRand())
+
+	// c1 is the top right square of the top left board, so O must play on
+	// the top right board, g1-i3
+	playUltimate(t, game, "c1")
+	if boardRow, boardColumn := game.ActiveBoard(); boardRow != 0 || boardColumn != 2 {
+		t.Errorf("ActiveBoard() = (%d,%d), want (0,2)", boardRow, boardColumn)
+	}
+	positions := game.GetAvailablePositions()
+	if len(positions) != 9 || positions[0] != "g1" || positions[8] != "i3" {
+		t.Errorf("GetAvailablePositions() = %v, want the nine squares g1-i3", positions)
+	}
+
+	rowIndex, columnIndex, _ := game.ParsePosition("e5")
+	err := game.MakeMove(rowIndex, columnIndex)
+	if err == nil || !strings.Contains(err.Error(), "g1-i3") {
+		t.Errorf("MakeMove(e5) error = %v, want one naming the g1-i3 board", err)
+	}
+	if game.CurrentPlayer != "O" {
+		t.Errorf("CurrentPlayer = %s after a rejected move, want O", game.CurrentPlayer)
+	}
+}
+
+func TestUltimateGame_WinningSmallBoards(t *testing.T) {
+	game := NewUltimateGame(LocalGame, newTestRand())
+
+	// X wins the top left board by completing a1, b1 and c1
+	game.boards[0][0][0][0], game.boards[0][0][0][1] = "X", "X"
+	game.boards[0][0][1][0], game.boards[1][0][0][0] = "O", "O"
+	game.active = [2]int{0, 0}
+	playUltimate(t, game, "c1")
+	if game.meta[0][0] != "X" {
+		t.Fatalf("top left board won by %q, want X", game.meta[0][0])
+	}
+
+	// O is sent to the top right board. Moves there sending X back to the
+	// won top left board let X play on any open board.
+	playUltimate(t, game, "g1")
+	if boardRow, _ := game.ActiveBoard(); boardRow != -1 {
+		t.Errorf("ActiveBoard() sends X to a won board, want any board")
+	}
+	rowIndex, columnIndex, _ := game.ParsePosition("b2")
+	if err := game.CheckMove(rowIndex, columnIndex); err == nil {
+		t.Error("CheckMove(b2) allowed a move on a won board")
+	}
+	if got := len(game.GetAvailablePositions()); got != 81-9-2 {
+		t.Errorf("GetAvailablePositions() has %d squares, want %d", got, 81-9-2)
+	}
+}
+
+func TestUltimateGame_GetWinner(t *testing.T) {
+	game := NewUltimateGame(LocalGame, newTestRand())
+	for boardColumn, mark := range []string{"X", "X", "X"} {
+		game.meta[1][boardColumn] = mark
+	}
+	if winner := game.GetWinner(); winner != "X" {
+		t.Errorf("GetWinner() = %q with the middle row of boards claimed, want X", winner)
+	}
+
+	drawn := NewUltimateGame(LocalGame, newTestRand())
+	full := Board{{"X", "O", "X"}, {"X", "O", "O"}, {"O", "X", "X"}}
+	for boardRow := range drawn.boards {
+		for boardColumn := range drawn.boards[boardRow] {
+			drawn.boards[boardRow][boardColumn] = full.clone()
+		}
+	}
+	if winner := drawn.GetWinner(); winner != "" {
+		t.Errorf("GetWinner() = %q with every board drawn, want no winner", winner)
+	}
+	if !drawn.IsBoardFull() {
+		t.Error("IsBoardFull() = false with every board drawn, want true")
+	}
+}
+
+func TestUltimateGame_ChooseSides(t *testing.T) {
+	game := NewUltimateGame(ComputerGame, newTestRand())
+	if err := game.ChooseSides("O", false); err != nil {
+		t.Fatalf("ChooseSides() unexpected error: %v", err)
+	}
+	if !game.IsComputerTurn() || game.ComputerMark != "X" {
+		t.Errorf("ChooseSides(O, false) left computer %s to move %v, want X to move", game.ComputerMark, game.IsComputerTurn())
+	}
+
+	if err := NewUltimateGame(LocalGame, newTestRand()).ChooseSides("X", true); err == nil {
+		t.Error("ChooseSides() allowed choosing sides in a local game")
+	}
+}
+
+func TestUltimateGame_GetComputerMove(t *testing.T) {
+	game := NewUltimateGame(ComputerGame, newTestRand())
+	game.Difficulty = Perfect
+
+	// O has two in a row on the big board and is one move from winning the
+	// third board, which X has just sent it to
+	game.meta[0][0], game.meta[0][1] = "O", "O"
+	game.boards[0][2][2][0], game.boards[0][2][2][1] = "O", "O"
+	game.boards[1][1][1][1] = "X"
+	game.active = [2]int{0, 2}
+	game.CurrentPlayer = "O"
+
+	rowIndex, columnIndex := game.GetComputerMove()
+	if name := game.PositionName(rowIndex, columnIndex); name != "i3" {
+		t.Errorf("GetComputerMove() = %s, want the winning square i3", name)
+	}
+}
+
+func TestUltimateGame_GetComputerMove_ForcedWin(t *testing.T) {
+	// X has two boards in a row on the big board and two in a row on the
+	// third, the top right board. X is on the bottom right board: g8 sends O
+	// to the middle left board, whose only open square c4 sends X back to the
+	// top right board to win with i3. No move wins at once, so the win is
+	// only found by searching past O's reply. h8 looks better on the way:
+	// O's only reply in the centre sends X to win the bottom left board.
+	setup := func(seed int64) *UltimateGame {
+		game := NewUltimateGame(ComputerGame, rand.New(rand.NewSource(seed)))
+		game.Difficulty = Perfect
+		game.ComputerMark, game.HumanMark = "X", "O"
+		game.meta[0][0], game.meta[0][1] = "X", "X"
+		game.boards[0][2] = Board{{"", "", ""}, {"O", "O", ""}, {"X", "X", ""}}
+		game.boards[1][0] = Board{{"X", "O", ""}, {"O", "O", "X"}, {"X", "X", "O"}}
+		game.boards[1][1] = Board{{"X", "O", "X"}, {"X", "O", "O"}, {"", "X", "X"}}
+		game.boards[2][0] = Board{{"X", "X", ""}, {"", "O", ""}, {"", "", ""}}
+		game.boards[2][2] = Board{{"O", "X", "O"}, {"", "", "X"}, {"", "O", "X"}}
+		game.active = [2]int{2, 2}
+		game.CurrentPlayer = "X"
+		return game
+	}
+
+	for seed := int64(0); seed < 20; seed++ {
+		game := setup(seed)
+		rowIndex, columnIndex := game.GetComputerMove()
+		if name := game.PositionName(rowIndex, columnIndex); name != "g8" {
+			t.Fatalf("GetComputerMove() with seed %d = %s, want g8, which forces a win", seed, name)
+		}
+	}
+
+	// Check the line really is forced
+	game := setup(0)
+	game.Mode = LocalGame
+	playUltimate(t, game, "g8", "c4", "i3")
+	if winner := game.GetWinner(); winner != "X" {
+		t.Errorf("GetWinner() = %q after g8 c4 i3, want X", winner)
+	}
+}
+
+func TestUltimateGame_ComputerGameFinishes(t *testing.T) {
+	game := NewUltimateGame(ComputerGame, newTestRand())
+	game.Difficulty = Medium
+	// Let the computer play both sides until the game ends
+	for moves := 0; game.GetWinner() == "" && !game.IsBoardFull(); moves++ {
+		if moves > 81 {
+			t.Fatal("game did not finish within 81 moves")
+		}
+		game.ComputerMark = game.CurrentPlayer
+		rowIndex, columnIndex := game.GetComputerMove()
+		if err := game.MakeMove(rowIndex, columnIndex); err != nil {
+			t.Fatalf("GetComputerMove() chose an illegal move (%d,%d): %v", rowIndex, columnIndex, err)
+		}
+	}
+}
+
+func TestUltimateGame_String(t *testing.T) {
+	game := NewUltimateGame(LocalGame, newTestRand())
+	playUltimate(t, game, "e5")
+
+	got := game.String()
+	for _, want := range []string{"a b c   d e f   g h i", "-------+-------+-------", "Boards won:", "Play on the d4-f6 board"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("String() = %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestGetPlayerMove_Ultimate(t *testing.T) {
+	game := NewUltimateGame(LocalGame, newTestRand())
+	playUltimate(t, game, "a1")
+
+	// X sent O to the top left board, so e5 is asked for again
+	mockP := &mockPrompter{inputAnswers: []string{"e5", "b2"}}
+	rowIndex, columnIndex, err := GetPlayerMove(mockP, game)
+	if err != nil {
+		t.Fatalf("GetPlayerMove() unexpected error: %v", err)
+	}
+	if rowIndex != 1 || columnIndex != 1 {
+		t.Errorf("GetPlayerMove() = (%d,%d), want (1,1) for b2", rowIndex, columnIndex)
+	}
+}
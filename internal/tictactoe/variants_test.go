@@ -0,0 +1,140 @@
+package tictactoe
+
+import "testing"
+
+func TestGetWinner_Variants(t *testing.T) {
+	tests := []struct {
+		name     string
+		variant  Variant
+		notation string
+		want     string
+	}{
+		{name: "standard line wins", variant: Standard, notation: "X1 O4 X2 O5 X3", want: "X"},
+		{name: "misere line loses", variant: Misere, notation: "X1 O4 X2 O5 X3", want: "O"},
+		{name: "misere without a line", variant: Misere, notation: "X1 O4 X2", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := ParseNotation(tt.notation, 3, 0)
+			if err != nil {
+				t.Fatalf("ParseNotation() unexpected error: %v", err)
+			}
+			game.Variant = tt.variant
+			if got := game.GetWinner(); got != tt.want {
+				t.Errorf("GetWinner() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWild_ChooseMark(t *testing.T) {
+	game := NewGame(LocalGame, newTestRand())
+	if err := game.ChooseMark("O"); err == nil {
+		t.Error("ChooseMark() in the standard game did not return an error")
+	}
+
+	game.Variant = Wild
+	if err := game.ChooseMark("Z"); err == nil {
+		t.Error("ChooseMark(Z) did not return an error")
+	}
+	// X places O on 1, 2 and 3 while O places X elsewhere, so X completes
+	// the line of O and wins
+	for _, move := range []struct {
+		mark                  string
+		rowIndex, columnIndex int
+	}{{"O", 0, 0}, {"X", 2, 2}, {"O", 0, 1}, {"X", 2, 0}, {"O", 0, 2}} {
+		if err := game.ChooseMark(move.mark); err != nil {
+			t.Fatalf("ChooseMark(%s) unexpected error: %v", move.mark, err)
+		}
+		if err := game.MakeMove(move.rowIndex, move.columnIndex); err != nil {
+			t.Fatalf("MakeMove() unexpected error: %v", err)
+		}
+	}
+	if got := game.GetWinner(); got != "X" {
+		t.Errorf("GetWinner() = %q, want X for completing the line of O", got)
+	}
+	if moves := game.Moves(); moves[0] != (Move{Player: "X", Mark: "O", RowIndex: 0, ColumnIndex: 0}) {
+		t.Errorf("Moves()[0] = %v, want X placing O on 1", moves[0])
+	}
+
+	// Without a choice a player places their own mark
+	game = NewGame(LocalGame, newTestRand())
+	game.Variant = Wild
+	if err := game.MakeMove(1, 1); err != nil {
+		t.Fatalf("MakeMove() unexpected error: %v", err)
+	}
+	if game.BoardString() != "....X...." {
+		t.Errorf("BoardString() = %q, want X on 5", game.BoardString())
+	}
+}
+
+func TestGetPlayerMove_WildAsksForTheMark(t *testing.T) {
+	game := NewGame(LocalGame, newTestRand())
+	game.Variant = Wild
+
+	// Square 5 is the fifth option, then O is the second mark
+	rowIndex, columnIndex, err := GetPlayerMove(&mockPrompter{selectAnswers: []int{4, 1}}, game)
+	if err != nil {
+		t.Fatalf("GetPlayerMove() unexpected error: %v", err)
+	}
+	if err := game.MakeMove(rowIndex, columnIndex); err != nil {
+		t.Fatalf("MakeMove() unexpected error: %v", err)
+	}
+	if game.BoardString() != "....O...." {
+		t.Errorf("BoardString() = %q, want O on 5", game.BoardString())
+	}
+}
+
+func TestComputerMove_Misere(t *testing.T) {
+	// X to move: 3 completes the top row and 5 the middle column
+	game, err := ParseBoard("XX.O.OOX.", 0)
+	if err != nil {
+		t.Fatalf("ParseBoard() unexpected error: %v", err)
+	}
+	game.Variant = Misere
+	game.rng = newTestRand()
+
+	rowIndex, columnIndex := game.SuggestMove(Perfect)
+	if name := game.PositionName(rowIndex, columnIndex); name != "9" {
+		t.Errorf("SuggestMove() = %s, want 9, the only move that does not complete a line", name)
+	}
+}
+
+func TestComputerMove_WildTakesTheWin(t *testing.T) {
+	// O to move can complete the top row by placing X on 3
+	game, err := ParseBoard("XX.O.....", 0)
+	if err != nil {
+		t.Fatalf("ParseBoard() unexpected error: %v", err)
+	}
+	game.Variant = Wild
+	game.rng = newTestRand()
+
+	rowIndex, columnIndex := game.SuggestMove(Perfect)
+	if err := game.MakeMove(rowIndex, columnIndex); err != nil {
+		t.Fatalf("MakeMove() unexpected error: %v", err)
+	}
+	if winner := game.GetWinner(); winner != "O" {
+		t.Errorf("GetWinner() = %q after O's move %s, want O to complete a line of X", winner, game.BoardString())
+	}
+}
+
+func TestAnalyze_Variants(t *testing.T) {
+	misere := NewGame(LocalGame, newTestRand())
+	misere.Variant = Misere
+	if analysis := misere.Analyze(); analysis.Value != "draw" {
+		t.Errorf("Analyze() of an empty misère board = %q, want a draw", analysis.Value)
+	}
+
+	wild := NewGame(LocalGame, newTestRand())
+	wild.Variant = Wild
+	analysis := wild.Analyze()
+	if analysis.Value != "X wins" {
+		t.Errorf("Analyze() of an empty Wild board = %q, want X wins", analysis.Value)
+	}
+	for _, move := range analysis.BestMoves {
+		if move[0] != 'X' && move[0] != 'O' {
+			t.Errorf("Analyze() best move %q does not name the mark to place", move)
+		}
+	}
+}
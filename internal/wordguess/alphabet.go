@@ -0,0 +1,126 @@
+package wordguess
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// Language is a language words can be guessed in.
+type Language struct {
+	Name     string       // Name is the language's English name, such as "German"
+	Tag      language.Tag // Tag decides how letters are lowercased
+	Alphabet []string     // Alphabet lists the letters offered as guesses, in order
+}
+
+var (
+	// English is the language of the bundled GitHub words
+	English = Language{
+		Name:     "English",
+		Tag:      language.English,
+		Alphabet: letters("abcdefghijklmnopqrstuvwxyz"),
+	}
+	// German adds umlauts and ß to the English letters
+	German = Language{
+		Name:     "German",
+		Tag:      language.German,
+		Alphabet: letters("abcdefghijklmnopqrstuvwxyzäöüß"),
+	}
+	// French adds accented letters and ligatures to the English letters
+	French = Language{
+		Name:     "French",
+		Tag:      language.French,
+		Alphabet: letters("abcdefghijklmnopqrstuvwxyzàâæçéèêëîïôœùûüÿ"),
+	}
+	// Japanese is written in hiragana, in gojūon order; katakana guesses are
+	// folded into hiragana
+	Japanese = Language{
+		Name: "Japanese",
+		Tag:  language.Japanese,
+		Alphabet: letters("あいうえおかきくけこがぎぐげごさしすせそざじずぜぞたちつてとだぢづでど" +
+			"なにぬねのはひふへほばびぶべぼぱぴぷぺぽまみむめもやゆよらりるれろわをん" +
+			"ぁぃぅぇぉっゃゅょ"),
+	}
+)
+
+// Languages lists the languages a word list can be detected as, in the order
+// they are tried
+var Languages = []Language{English, German, French, Japanese}
+
+// DetectLanguage returns the first of Languages whose alphabet has every
+// letter of words. If none does, English is returned with the missing letters
+// added to the end of its alphabet, so every letter can still be offered.
+func DetectLanguage(words []string) Language {
+	used := map[string]bool{}
+	var order []string
+	for _, word := range words {
+		for _, letter := range letters(word) {
+			if !used[letter] {
+				used[letter] = true
+				order = append(order, letter)
+			}
+		}
+	}
+
+	for _, lang := range Languages {
+		if !slices.ContainsFunc(order, func(letter string) bool { return !slices.Contains(lang.Alphabet, letter) }) {
+			return lang
+		}
+	}
+
+	lang := English
+	lang.Alphabet = slices.Clone(English.Alphabet)
+	for _, letter := range order {
+		if !slices.Contains(lang.Alphabet, letter) {
+			lang.Alphabet = append(lang.Alphabet, letter)
+		}
+	}
+	return lang
+}
+
+// fold puts text in the form words and guesses are compared in: full-width
+// and half-width forms are narrowed or widened to their usual width,
+// characters are composed, letters are lowercased by the rules of the
+// language tag and katakana is turned into hiragana.
+func fold(text string, tag language.Tag) string {
+	text = norm.NFC.String(width.Fold.String(text))
+	text = cases.Lower(tag).String(text)
+	return strings.Map(func(r rune) rune {
+		// The katakana block mirrors hiragana 0x60 code points later
+		if r >= 'ァ' && r <= 'ヶ' {
+			return r - 0x60
+		}
+		return r
+	}, text)
+}
+
+// letters splits text into the letters a player sees, keeping each combining
+// mark with the character before it, so a letter may be more than one rune.
+func letters(text string) []string {
+	var result []string
+	for _, r := range text {
+		if len(result) > 0 && unicode.In(r, unicode.Mn, unicode.Me) {
+			result[len(result)-1] += string(r)
+			continue
+		}
+		result = append(result, string(r))
+	}
+	return result
+}
+
+// isLetter reports whether text is a single letter, possibly with combining
+// marks.
+func isLetter(text string) bool {
+	split := letters(text)
+	if len(split) != 1 {
+		return false
+	}
+	first, _ := utf8.DecodeRuneInString(split[0])
+	return unicode.IsLetter(first)
+}
@@ -0,0 +1,157 @@
+package wordguess
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestLetters(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"git", []string{"g", "i", "t"}},
+		{"größe", []string{"g", "r", "ö", "ß", "e"}},
+		{"café", []string{"c", "a", "f", "é"}},
+		{"でんしゃ", []string{"で", "ん", "し", "ゃ"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		if got := letters(tt.text); !slices.Equal(got, tt.want) {
+			t.Errorf("letters(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"GitHub", "github"},
+		{"Ü", "ü"},
+		{"STRAẞE", "straße"},
+		{"É", "é"},
+		{"e\u0301", "é"}, // composed into one letter
+		{"ＡＢ", "ab"},     // full-width letters narrowed
+		{"カ", "か"},       // katakana turned into hiragana
+		{"ｶﾞ", "が"},      // half-width katakana widened and composed
+	}
+
+	for _, tt := range tests {
+		if got := fold(tt.text, language.Und); got != tt.want {
+			t.Errorf("fold(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+		want  string
+	}{
+		{"english", []string{"commit", "branch"}, "English"},
+		{"german", []string{"commit", "größe"}, "German"},
+		{"french", []string{"dépôt", "branche"}, "French"},
+		{"japanese", []string{"さくら", "でんしゃ"}, "Japanese"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectLanguage(tt.words); got.Name != tt.want {
+				t.Errorf("DetectLanguage(%v) = %s, want %s", tt.words, got.Name, tt.want)
+			}
+		})
+	}
+
+	// Letters no language has are added to the English alphabet
+	got := DetectLanguage([]string{"ångström", "ord"})
+	if !slices.Contains(got.Alphabet, "å") || !slices.Contains(got.Alphabet, "ö") || len(got.Alphabet) != 28 {
+		t.Errorf("DetectLanguage() alphabet = %v, want English with å and ö", got.Alphabet)
+	}
+	if len(English.Alphabet) != 26 {
+		t.Errorf("DetectLanguage() changed the English alphabet to %v", English.Alphabet)
+	}
+}
+
+func TestBundledCategoryLanguages(t *testing.T) {
+	for category, want := range map[string]string{"github": "English", "german": "German", "french": "French", "japanese": "Japanese"} {
+		words, err := CategoryWords(category)
+		if err != nil {
+			t.Fatalf("CategoryWords(%q) unexpected error: %v", category, err)
+		}
+		if got := DetectLanguage(words); got.Name != want {
+			t.Errorf("DetectLanguage(%s words) = %s, want %s", category, got.Name, want)
+		}
+	}
+}
+
+func TestGuessLetter_Unicode(t *testing.T) {
+	tests := []struct {
+		name         string
+		words        []string
+		guesses      []string
+		wantRevealed string
+		wantWon      bool
+	}{
+		{
+			name:         "german",
+			words:        []string{"Größe"},
+			guesses:      []string{"Ö", "e", "ß"},
+			wantRevealed: "__öße",
+		},
+		{
+			name:         "french decomposed guess",
+			words:        []string{"clé"},
+			guesses:      []string{"c", "l", "e\u0301"},
+			wantRevealed: "clé",
+			wantWon:      true,
+		},
+		{
+			name:         "japanese with katakana guesses",
+			words:        []string{"でんしゃ"},
+			guesses:      []string{"デ", "ん", "シ", "ャ"},
+			wantRevealed: "でんしゃ",
+			wantWon:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewGameFromList(tt.words, newTestRand())
+			if got := strings.Count(game.RevealedWord, "_"); got != len(letters(game.Word)) {
+				t.Fatalf("NewGameFromList() revealed %q, want one _ per letter of %q", game.RevealedWord, game.Word)
+			}
+			for _, guess := range tt.guesses {
+				if err := game.GuessLetter(guess); err != nil {
+					t.Fatalf("GuessLetter(%q) unexpected error: %v", guess, err)
+				}
+			}
+			if game.RevealedWord != tt.wantRevealed || game.HasWon != tt.wantWon {
+				t.Errorf("RevealedWord = %q, HasWon = %v, want %q, %v", game.RevealedWord, game.HasWon, tt.wantRevealed, tt.wantWon)
+			}
+			if game.IncorrectGuesses != 0 {
+				t.Errorf("IncorrectGuesses = %d, want 0", game.IncorrectGuesses)
+			}
+		})
+	}
+}
+
+func TestGetRemainingLetters_Language(t *testing.T) {
+	game := NewGameFromList([]string{"ねこ"}, newTestRand())
+	if err := game.GuessLetter("ね"); err != nil {
+		t.Fatalf("GuessLetter() unexpected error: %v", err)
+	}
+	remaining := letters(game.GetRemainingLetters())
+	if len(remaining) != len(Japanese.Alphabet)-1 || slices.Contains(remaining, "ね") || !slices.Contains(remaining, "こ") {
+		t.Errorf("GetRemainingLetters() = %q, want the hiragana but ね", remaining)
+	}
+	if !strings.Contains(game.String(), "ね _") {
+		t.Errorf("String() does not show the revealed ね:\n%s", game.String())
+	}
+}
@@ -0,0 +1,65 @@
+package wordguess
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// NewEvilGame creates a Word Guess game that does not commit to a word. The
+// length of the word is that of a word picked from words using rng, and every
+// word of that length stays a candidate. Each guess splits the candidates by
+// where the letter is in them and keeps the largest class, so the player is
+// dodged for as long as the words allow. Word is always one of the
+// candidates, and so fits every guess made.
+func NewEvilGame(words []string, rng *rand.Rand) *Game {
+	game := NewGameFromList(words, rng)
+	length := len(letters(game.Word))
+	for _, word := range words {
+		word = fold(word, game.Language.Tag)
+		if len(letters(word)) == length && !slices.Contains(game.candidates, word) {
+			game.candidates = append(game.candidates, word)
+		}
+	}
+	return game
+}
+
+// dodge narrows the candidates to the largest class of words that have letter
+// in the same places, and makes the first of them the word. Of classes the
+// same size, the one revealing the fewest letters is kept, so a miss beats a
+// hit, and then the one found first.
+func (g *Game) dodge(letter string) {
+	classes := map[string][]string{}
+	var patterns []string
+	for _, word := range g.candidates {
+		pattern := letterPattern(word, letter)
+		if _, ok := classes[pattern]; !ok {
+			patterns = append(patterns, pattern)
+		}
+		classes[pattern] = append(classes[pattern], word)
+	}
+
+	best := patterns[0]
+	for _, pattern := range patterns[1:] {
+		size, bestSize := len(classes[pattern]), len(classes[best])
+		if size > bestSize || (size == bestSize && strings.Count(pattern, letter) < strings.Count(best, letter)) {
+			best = pattern
+		}
+	}
+	g.candidates = classes[best]
+	g.Word = g.candidates[0]
+}
+
+// letterPattern returns word with every letter but letter hidden, such as
+// "_e__e" for letter e in merge
+func letterPattern(word, letter string) string {
+	var pattern strings.Builder
+	for _, char := range letters(word) {
+		if char == letter {
+			pattern.WriteString(char)
+		} else {
+			pattern.WriteString("_")
+		}
+	}
+	return pattern.String()
+}
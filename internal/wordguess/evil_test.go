@@ -0,0 +1,150 @@
+package wordguess
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/chrisreddington/gh-game/internal/output"
+	"github.com/chrisreddington/gh-game/internal/stats"
+)
+
+func TestLetterPattern(t *testing.T) {
+	tests := []struct {
+		word   string
+		letter string
+		want   string
+	}{
+		{"merge", "e", "_e__e"},
+		{"merge", "z", "_____"},
+		{"größe", "ö", "__ö__"},
+	}
+
+	for _, tt := range tests {
+		if got := letterPattern(tt.word, tt.letter); got != tt.want {
+			t.Errorf("letterPattern(%q, %q) = %q, want %q", tt.word, tt.letter, got, tt.want)
+		}
+	}
+}
+
+func TestNewEvilGame(t *testing.T) {
+	game := NewEvilGame([]string{"bat", "Cat", "fork", "hat", "cat", "gist"}, newTestRand())
+	length := len(letters(game.Word))
+	for _, word := range game.candidates {
+		if len(letters(word)) != length {
+			t.Errorf("NewEvilGame() candidate %q is not %d letters", word, length)
+		}
+	}
+	want := map[int]int{3: 3, 4: 2}[length]
+	if len(game.candidates) != want || !slices.Contains(game.candidates, game.Word) {
+		t.Errorf("NewEvilGame() candidates = %v, word %q, want the %d different words of %d letters", game.candidates, game.Word, want, length)
+	}
+}
+
+func TestGuessLetter_Evil(t *testing.T) {
+	game := NewEvilGame([]string{"bat", "cat", "hat", "ant", "tan", "sip"}, rand.New(rand.NewSource(0)))
+
+	steps := []struct {
+		guess          string
+		wantRevealed   string
+		wantCandidates []string
+	}{
+		// _a_ is the largest class: bat, cat, hat and tan
+		{"a", "_a_", []string{"bat", "cat", "hat", "tan"}},
+		// __t holds three of them, t__ only one
+		{"t", "_at", []string{"bat", "cat", "hat"}},
+		// Every class is one word, so the miss is kept
+		{"c", "_at", []string{"bat", "hat"}},
+		{"b", "_at", []string{"hat"}},
+		{"h", "hat", []string{"hat"}},
+	}
+	for _, step := range steps {
+		if err := game.GuessLetter(step.guess); err != nil {
+			t.Fatalf("GuessLetter(%q) unexpected error: %v", step.guess, err)
+		}
+		if game.RevealedWord != step.wantRevealed || !slices.Equal(game.candidates, step.wantCandidates) {
+			t.Errorf("after %q revealed = %q, candidates = %v, want %q, %v", step.guess, game.RevealedWord, game.candidates, step.wantRevealed, step.wantCandidates)
+		}
+	}
+	if !game.HasWon || game.IncorrectGuesses != 2 {
+		t.Errorf("HasWon = %v, IncorrectGuesses = %d, want a win after 2 misses", game.HasWon, game.IncorrectGuesses)
+	}
+}
+
+// TestGuessLetter_EvilNeverContradicts plays many games of random guesses on
+// every bundled list, checking after each guess that every candidate, the word
+// among them, fits everything the player has been shown.
+func TestGuessLetter_EvilNeverContradicts(t *testing.T) {
+	for _, category := range Categories() {
+		words, err := CategoryWords(category)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rng := rand.New(rand.NewSource(1))
+		for range 50 {
+			game := NewEvilGame(words, rng)
+			game.MaxIncorrect = 10
+			alphabet := game.language().Alphabet
+			for _, i := range rng.Perm(len(alphabet)) {
+				if game.IsOver {
+					break
+				}
+				if err := game.GuessLetter(alphabet[i]); err != nil {
+					t.Fatalf("GuessLetter(%q) unexpected error: %v", alphabet[i], err)
+				}
+				if !slices.Contains(game.candidates, game.Word) {
+					t.Fatalf("%s: word %q is not a candidate", category, game.Word)
+				}
+				for _, word := range game.candidates {
+					if !fitsGuesses(game, word) {
+						t.Fatalf("%s: candidate %q contradicts %q after guessing %v", category, word, game.RevealedWord, game.GuessedLetters)
+					}
+				}
+			}
+			if game.HasWon && game.RevealedWord != game.Word {
+				t.Errorf("%s: won with %q revealed, but the word is %q", category, game.RevealedWord, game.Word)
+			}
+		}
+	}
+}
+
+// fitsGuesses reports whether word could be the word of game: it has every
+// revealed letter in place, and no guessed letter anywhere still hidden
+func fitsGuesses(game *Game, word string) bool {
+	revealed, wordLetters := letters(game.RevealedWord), letters(word)
+	if len(revealed) != len(wordLetters) {
+		return false
+	}
+	misses := 0
+	for _, guess := range game.GuessedLetters {
+		if !slices.Contains(wordLetters, guess) {
+			misses++
+		}
+	}
+	if misses != game.IncorrectGuesses {
+		return false
+	}
+	for i, letter := range wordLetters {
+		if revealed[i] == "_" && slices.Contains(game.GuessedLetters, letter) {
+			return false
+		}
+		if revealed[i] != "_" && revealed[i] != letter {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPlayGame_Evil(t *testing.T) {
+	mp := &MockPrompter{
+		InputResponses:   []string{"a", "t", "c", "b", "h"},
+		ConfirmResponses: []bool{false},
+	}
+	got := PlayGame(mp, output.Discard, rand.New(rand.NewSource(0)), Options{
+		Words: []string{"bat", "cat", "hat", "ant", "tan", "sip"},
+		Evil:  true,
+	})
+	if want := (stats.Result{Played: 1, Wins: 1, Streak: 1}); got != want {
+		t.Errorf("PlayGame() = %+v, want %+v", got, want)
+	}
+}
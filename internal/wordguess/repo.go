@@ -0,0 +1,236 @@
+package wordguess
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// harvestMinLength and harvestMaxLength bound the length of words
+	// harvested from a repository, leaving out short words that are too easy
+	// and long ones that are too hard
+	harvestMinLength = 5
+	harvestMaxLength = 12
+	// harvestMinCount is how many times a word must appear in a repository
+	// to be harvested, so typos and one-off names are left out
+	harvestMinCount = 2
+	// harvestMaxFileSize is the largest file words are harvested from, so
+	// generated and vendored blobs do not slow the harvest down
+	harvestMaxFileSize = 1 << 20
+	// harvestCommits is how many recent commit messages are harvested from
+	harvestCommits = 500
+)
+
+// sourceExtensions lists the file extensions whose identifiers are harvested
+var sourceExtensions = map[string]bool{
+	".go": true, ".js": true, ".jsx": true, ".ts": true, ".tsx": true,
+	".py": true, ".rb": true, ".java": true, ".kt": true, ".swift": true,
+	".rs": true, ".c": true, ".h": true, ".cpp": true, ".cs": true,
+	".php": true, ".sh": true, ".scala": true, ".ex": true, ".lua": true,
+}
+
+// stopWords are too common in code or English to make interesting words
+var stopWords = wordSet(`
+		about above after again against because before being below between
+		could doing during every further their there these those through under
+		until where which while would should other first might never since
+		still though using without within among along around
+		break catch class const continue default defer delete false final
+		float import interface package private public return static string
+		struct super switch throw throws while yield error errors value
+		values https github readme example examples begin println printf
+		sprintf errorf`)
+
+// wordSet returns the set of the words in a space-separated list.
+func wordSet(list string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range strings.Fields(list) {
+		set[word] = true
+	}
+	return set
+}
+
+// Repo holds the parts of a git repository words are harvested from.
+type Repo struct {
+	Files    fs.FS    // Files is the repository's working tree
+	Paths    []string // Paths are the tracked files in Files, with slashes
+	Branches []string // Branches are the names of the local branches
+	Commits  []Commit // Commits are recent commits, newest first
+}
+
+// Commit is a commit whose message words are harvested from.
+type Commit struct {
+	Hash    string // Hash is the commit's abbreviated hash
+	Subject string // Subject is the first line of the commit message
+}
+
+// WordPool is a list of words to guess, along with where each was found.
+type WordPool struct {
+	Words   []string          // Words are the words, in the order they were first found
+	Origins map[string]string // Origins says where each word was first found, such as "cmd/root.go:12"
+}
+
+// OpenRepo reads the git repository containing dir: its tracked files, local
+// branches and recent commits.
+// Returns an error if git is not installed or dir is not in a repository.
+func OpenRepo(dir string) (*Repo, error) {
+	root, err := runGit(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, fmt.Errorf("not in a git repository: %w", err)
+	}
+	root = strings.TrimSpace(root)
+
+	files, err := runGit(root, "ls-files", "-z")
+	if err != nil {
+		return nil, err
+	}
+	branches, err := runGit(root, "branch", "--format=%(refname:short)")
+	if err != nil {
+		return nil, err
+	}
+	// A repository without commits has no log, which is not an error here
+	log, _ := runGit(root, "log", "-n", strconv.Itoa(harvestCommits), "--format=%h %s")
+
+	repo := &Repo{
+		Files:    os.DirFS(root),
+		Paths:    strings.FieldsFunc(files, func(r rune) bool { return r == 0 }),
+		Branches: strings.Fields(branches),
+	}
+	for _, line := range strings.Split(strings.TrimSpace(log), "\n") {
+		if hash, subject, ok := strings.Cut(line, " "); ok {
+			repo.Commits = append(repo.Commits, Commit{Hash: hash, Subject: subject})
+		}
+	}
+	return repo, nil
+}
+
+// runGit runs git in dir and returns what it prints.
+func runGit(dir string, args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", errors.New(message)
+		}
+		return "", err
+	}
+	return string(out), nil
+}
+
+// Harvest collects the words of the repository: identifiers in source files,
+// split at capitals and underscores, every word of README files, the words of
+// headings in other markdown files, and the words of branch names and commit
+// messages. Only words of harvestMinLength to harvestMaxLength letters that
+// appear at least harvestMinCount times, and are not stop words, are kept.
+func (r *Repo) Harvest() *WordPool {
+	counts := map[string]int{}
+	pool := &WordPool{Origins: map[string]string{}}
+	add := func(text, origin string) {
+		for _, word := range splitWords(text) {
+			if length := utf8.RuneCountInString(word); length < harvestMinLength || length > harvestMaxLength || stopWords[word] {
+				continue
+			}
+			counts[word]++
+			if _, ok := pool.Origins[word]; !ok {
+				pool.Origins[word] = origin
+				pool.Words = append(pool.Words, word)
+			}
+		}
+	}
+
+	for _, name := range r.Paths {
+		r.harvestFile(name, add)
+	}
+	for _, branch := range r.Branches {
+		add(branch, "branch "+branch)
+	}
+	for _, commit := range r.Commits {
+		add(commit.Subject, "commit "+commit.Hash)
+	}
+
+	words := pool.Words[:0]
+	for _, word := range pool.Words {
+		if counts[word] >= harvestMinCount {
+			words = append(words, word)
+		} else {
+			delete(pool.Origins, word)
+		}
+	}
+	pool.Words = words
+	return pool
+}
+
+// harvestFile passes each line of a file worth harvesting to add, along with
+// where it is, such as "cmd/root.go:12".
+func (r *Repo) harvestFile(name string, add func(text, origin string)) {
+	extension := strings.ToLower(path.Ext(name))
+	readme := strings.HasPrefix(strings.ToLower(path.Base(name)), "readme")
+	markdown := extension == ".md" || extension == ".markdown"
+	if !sourceExtensions[extension] && !markdown && !readme {
+		return
+	}
+	if info, err := fs.Stat(r.Files, name); err != nil || info.Size() > harvestMaxFileSize {
+		return
+	}
+	file, err := r.Files.Open(name)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, harvestMaxFileSize)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if markdown && !readme && !strings.HasPrefix(strings.TrimSpace(text), "#") {
+			continue
+		}
+		add(text, name+":"+strconv.Itoa(line))
+	}
+}
+
+// splitWords splits text into lowercase words made of letters, breaking
+// identifiers such as newGameSession, HTTPServer and max_count into their
+// parts. Letters of any script count, so café is one word.
+func splitWords(text string) []string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+
+	runes := []rune(text)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(word) > 0 {
+			previousLower := unicode.IsLower(runes[i-1])
+			// The last capital of an acronym starts the next word, as in
+			// HTTPServer
+			acronymEnd := i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])
+			if previousLower || acronymEnd {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+	return words
+}
@@ -0,0 +1,168 @@
+package wordguess
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/chrisreddington/gh-game/internal/output"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"newGameSession", []string{"new", "game", "session"}},
+		{"HTTPServer", []string{"http", "server"}},
+		{"max_count", []string{"max", "count"}},
+		{"MAX_COUNT", []string{"max", "count"}},
+		{"## Getting started", []string{"getting", "started"}},
+		{"func parse2Words(x int)", []string{"func", "parse", "words", "x", "int"}},
+		{"café", []string{"café"}},
+		{"größeFehler", []string{"größe", "fehler"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		if got := splitWords(tt.text); !slices.Equal(got, tt.want) {
+			t.Errorf("splitWords(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestHarvest(t *testing.T) {
+	repo := &Repo{
+		Files: fstest.MapFS{
+			"main.go":       {Data: []byte("package main\n\nfunc renderBoard() {}\nfunc drawBoard() {}\n")},
+			"README.md":     {Data: []byte("# Tiles\n\nRender tiles to the terminal.\n")},
+			"docs/guide.md": {Data: []byte("# Terminal colours\n\nThe palette is only mentioned in text.\npalette\n")},
+			"image.png":     {Data: []byte("render render render")},
+		},
+		Paths:    []string{"main.go", "README.md", "docs/guide.md", "image.png"},
+		Branches: []string{"main", "feature/scoring"},
+		Commits: []Commit{
+			{Hash: "a1b2c3d", Subject: "Add scoring to the board"},
+			{Hash: "e4f5a6b", Subject: "Fix scoring"},
+		},
+	}
+
+	pool := repo.Harvest()
+	want := []string{"render", "board", "tiles", "terminal", "scoring"}
+	if !slices.Equal(pool.Words, want) {
+		t.Errorf("Harvest() words = %v, want %v", pool.Words, want)
+	}
+
+	origins := map[string]string{
+		"render":   "main.go:3",
+		"board":    "main.go:3",
+		"tiles":    "README.md:1",
+		"terminal": "README.md:3",
+		"scoring":  "branch feature/scoring",
+	}
+	for word, origin := range origins {
+		if got := pool.Origins[word]; got != origin {
+			t.Errorf("Harvest() origin of %q = %q, want %q", word, got, origin)
+		}
+	}
+	if len(pool.Origins) != len(pool.Words) {
+		t.Errorf("Harvest() has %d origins for %d words", len(pool.Origins), len(pool.Words))
+	}
+}
+
+func TestHarvest_Unicode(t *testing.T) {
+	repo := &Repo{
+		Files: fstest.MapFS{
+			// café is four letters, though it is five bytes
+			"README.md": {Data: []byte("# Größe\n\nDie Größe im café, café.\n")},
+		},
+		Paths: []string{"README.md"},
+	}
+	pool := repo.Harvest()
+	if !slices.Equal(pool.Words, []string{"größe"}) {
+		t.Errorf("Harvest() words = %v, want [größe]", pool.Words)
+	}
+}
+
+func TestHarvest_CommitOrigin(t *testing.T) {
+	repo := &Repo{
+		Files:   fstest.MapFS{},
+		Commits: []Commit{{Hash: "a1b2c3d", Subject: "Speed up the solver"}, {Hash: "e4f5a6b", Subject: "Test the solver"}},
+	}
+	pool := repo.Harvest()
+	if !slices.Equal(pool.Words, []string{"solver"}) || pool.Origins["solver"] != "commit a1b2c3d" {
+		t.Errorf("Harvest() = %v %v, want solver from commit a1b2c3d", pool.Words, pool.Origins)
+	}
+}
+
+func TestOpenRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q", "-b", "trunk")
+	if err := os.WriteFile(filepath.Join(dir, "tracked.go"), []byte("var widgetCount, widgetSize int\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "untracked.go"), []byte("var gadget, gadget int\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", "tracked.go")
+	git("commit", "-q", "-m", "Initial commit")
+
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := OpenRepo(filepath.Join(dir, "sub"))
+	if err != nil {
+		t.Fatalf("OpenRepo() unexpected error: %v", err)
+	}
+	if !slices.Equal(repo.Paths, []string{"tracked.go"}) {
+		t.Errorf("OpenRepo() paths = %v, want only the tracked file", repo.Paths)
+	}
+	if !slices.Equal(repo.Branches, []string{"trunk"}) {
+		t.Errorf("OpenRepo() branches = %v, want [trunk]", repo.Branches)
+	}
+	if len(repo.Commits) != 1 || repo.Commits[0].Subject != "Initial commit" {
+		t.Errorf("OpenRepo() commits = %v, want the one commit", repo.Commits)
+	}
+
+	pool := repo.Harvest()
+	if !slices.Equal(pool.Words, []string{"widget"}) || pool.Origins["widget"] != "tracked.go:1" {
+		t.Errorf("Harvest() = %v %v, want widget from tracked.go:1", pool.Words, pool.Origins)
+	}
+
+	if _, err := OpenRepo(t.TempDir()); err == nil || !strings.Contains(err.Error(), "not in a git repository") {
+		t.Errorf("OpenRepo() outside a repository error = %v", err)
+	}
+}
+
+func TestPlayGame_ShowsOrigin(t *testing.T) {
+	mp := &MockPrompter{
+		InputResponses:   []string{"m", "o", "n", "a"},
+		ConfirmResponses: []bool{false},
+	}
+	var buf bytes.Buffer
+	PlayGame(mp, output.NewText(&buf), newTestRand(), Options{
+		Words:   []string{"mona"},
+		Topic:   "word from this repository",
+		Origins: map[string]string{"mona": "README.md:4"},
+	})
+	for _, want := range []string{"word from this repository", "The word came from README.md:4"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("PlayGame() output does not contain %q:\n%s", want, buf.String())
+		}
+	}
+}
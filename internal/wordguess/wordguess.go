@@ -5,24 +5,27 @@ package wordguess
 import (
 	"fmt"
 	"math/rand"
+	"slices"
 	"strings"
 
-	"github.com/charmbracelet/lipgloss"
+	"github.com/chrisreddington/gh-game/internal/output"
+	"github.com/chrisreddington/gh-game/internal/stats"
+	"github.com/chrisreddington/gh-game/internal/theme"
 )
 
 const (
-	// MaxIncorrectGuesses is the maximum number of incorrect guesses allowed before losing
+	// MaxIncorrectGuesses is the default number of incorrect guesses allowed before losing
 	MaxIncorrectGuesses = 6
 )
 
 var (
 	// Stylized output for the game
-	titleStyle       = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
-	wordStyle        = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
-	correctStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("10")) // green
-	incorrectStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))  // red
-	remainingStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("15")) // white
-	instructionStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+	titleStyle       = theme.Title
+	wordStyle        = theme.Highlight
+	correctStyle     = theme.Success
+	incorrectStyle   = theme.Failure
+	remainingStyle   = theme.Text
+	instructionStyle = theme.Accent
 )
 
 // Game represents the state of a word guess game
@@ -33,14 +36,32 @@ type Game struct {
 	IncorrectGuesses int      // Number of incorrect guesses
 	IsOver           bool     // Whether the game is over
 	HasWon           bool     // Whether the player has won
+	MaxIncorrect     int      // Incorrect guesses allowed before losing, MaxIncorrectGuesses if zero
+	Language         Language // The language of the word, English if it has no alphabet
+
+	// candidates are the words still consistent with the guesses in evil
+	// mode, nil otherwise
+	candidates []string
 }
 
-// WordList contains a selection of words for the game
-var WordList = []string{
-	"github", "actions", "workflow", "repository", "branch",
-	"commit", "merge", "issues", "pull", "request", "codespace",
-	"copilot", "project", "discussion", "milestone", "release",
-	"clone", "fork", "gist", "markdown", "license", "readme",
+// WordList contains the words a game picks from unless it is given its own,
+// the bundled DefaultCategory
+var WordList = mustCategory(DefaultCategory)
+
+// Options holds the settings for a session of Word Guess.
+type Options struct {
+	MaxIncorrect int      // MaxIncorrect is the incorrect guesses allowed for each word, MaxIncorrectGuesses if zero
+	Words        []string // Words are the words to pick from, WordList if empty
+	Topic        string   // Topic says what the words are when introducing each one, such as "Git term"; "word" if empty
+	// Origins says where each word came from, such as "cmd/root.go:12",
+	// which is shown once the word is over
+	Origins map[string]string
+	// HardMode makes every hint revealed in Wordle mode a must in later
+	// guesses
+	HardMode bool
+	// Evil keeps the word unsettled in the classic game, changing it to dodge
+	// each guess; see NewEvilGame
+	Evil bool
 }
 
 // Prompter interface allows us to mock the prompt functionality in tests
@@ -50,13 +71,23 @@ type Prompter interface {
 	Confirm(prompt string, defaultValue bool) (bool, error)
 }
 
-// NewGame creates and initializes a new Word Guess game
-func NewGame() *Game {
-	word := WordList[rand.Intn(len(WordList))]
+// NewGame creates and initializes a new Word Guess game,
+// picking the word to guess from WordList using rng
+func NewGame(rng *rand.Rand) *Game {
+	return NewGameFromList(WordList, rng)
+}
+
+// NewGameFromList creates and initializes a new Word Guess game, picking the
+// word to guess from words using rng. The game's language is the one detected
+// for the whole list, so every word of a list offers the same letters.
+func NewGameFromList(words []string, rng *rand.Rand) *Game {
+	lang := DetectLanguage(words)
+	word := fold(words[rng.Intn(len(words))], lang.Tag)
 
 	return &Game{
-		Word:             strings.ToLower(word),
-		RevealedWord:     strings.Repeat("_", len(word)),
+		Word:             word,
+		RevealedWord:     strings.Repeat("_", len(letters(word))),
+		Language:         lang,
 		GuessedLetters:   []string{},
 		IncorrectGuesses: 0,
 		IsOver:           false,
@@ -66,11 +97,11 @@ func NewGame() *Game {
 
 // GuessLetter processes a letter guess and updates the game state
 func (g *Game) GuessLetter(letter string) error {
-	// Convert to lowercase
-	letter = strings.ToLower(letter)
+	// Convert to lowercase, and the other forms of letters to the usual ones
+	letter = fold(letter, g.language().Tag)
 
 	// Validate input
-	if len(letter) != 1 || !isLetter(letter[0]) {
+	if !isLetter(letter) {
 		return fmt.Errorf("please enter a single letter")
 	}
 
@@ -84,16 +115,21 @@ func (g *Game) GuessLetter(letter string) error {
 	// Add to guessed letters
 	g.GuessedLetters = append(g.GuessedLetters, letter)
 
+	// In evil mode, pick the word to dodge the guess as well as possible
+	if g.candidates != nil {
+		g.dodge(letter)
+	}
+
 	// Check if letter is in the word
-	if strings.Contains(g.Word, letter) {
+	if g.contains(letter) {
 		// Update revealed word
-		newRevealedWord := []rune(g.RevealedWord)
-		for i, char := range g.Word {
-			if string(char) == letter {
+		newRevealedWord := letters(g.RevealedWord)
+		for i, char := range letters(g.Word) {
+			if char == letter {
 				newRevealedWord[i] = char
 			}
 		}
-		g.RevealedWord = string(newRevealedWord)
+		g.RevealedWord = strings.Join(newRevealedWord, "")
 
 		// Check if the word is completely revealed (win condition)
 		if !strings.Contains(g.RevealedWord, "_") {
@@ -105,7 +141,7 @@ func (g *Game) GuessLetter(letter string) error {
 		g.IncorrectGuesses++
 
 		// Check if max incorrect guesses reached (lose condition)
-		if g.IncorrectGuesses >= MaxIncorrectGuesses {
+		if g.IncorrectGuesses >= g.maxIncorrect() {
 			g.IsOver = true
 		}
 	}
@@ -113,27 +149,35 @@ func (g *Game) GuessLetter(letter string) error {
 	return nil
 }
 
-// isLetter checks if a byte is a letter
-func isLetter(c byte) bool {
-	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+// maxIncorrect returns the number of incorrect guesses allowed in this game
+func (g *Game) maxIncorrect() int {
+	if g.MaxIncorrect <= 0 {
+		return MaxIncorrectGuesses
+	}
+	return g.MaxIncorrect
 }
 
-// GetRemainingLetters returns a string of letters that haven't been guessed yet
+// language returns the language of the word, English if none was set
+func (g *Game) language() Language {
+	if len(g.Language.Alphabet) == 0 {
+		return English
+	}
+	return g.Language
+}
+
+// contains reports whether letter is one of the letters of the word
+func (g *Game) contains(letter string) bool {
+	return slices.Contains(letters(g.Word), letter)
+}
+
+// GetRemainingLetters returns a string of the letters of the game's alphabet
+// that haven't been guessed yet
 func (g *Game) GetRemainingLetters() string {
-	alphabet := "abcdefghijklmnopqrstuvwxyz"
 	var remaining strings.Builder
 
-	for _, char := range alphabet {
-		found := false
-		for _, guessed := range g.GuessedLetters {
-			if string(char) == guessed {
-				found = true
-				break
-			}
-		}
-
-		if !found {
-			remaining.WriteRune(char)
+	for _, char := range g.language().Alphabet {
+		if !slices.Contains(g.GuessedLetters, char) {
+			remaining.WriteString(char)
 		}
 	}
 
@@ -148,8 +192,8 @@ func (g *Game) String() string {
 	sb.WriteString(titleStyle.Render("W O R D  G U E S S") + "\n\n")
 
 	// Display the remaining guesses prominently
-	incorrectLeft := MaxIncorrectGuesses - g.IncorrectGuesses
-	guessesDisplay := fmt.Sprintf("Guesses Remaining: %d/%d", incorrectLeft, MaxIncorrectGuesses)
+	incorrectLeft := g.maxIncorrect() - g.IncorrectGuesses
+	guessesDisplay := fmt.Sprintf("Guesses Remaining: %d/%d", incorrectLeft, g.maxIncorrect())
 
 	if incorrectLeft > 3 {
 		sb.WriteString(correctStyle.Render(guessesDisplay))
@@ -162,19 +206,15 @@ func (g *Game) String() string {
 
 	// Display the word with guessed letters
 	displayWord := ""
-	for _, char := range g.RevealedWord {
-		if char == '_' {
-			displayWord += "_ "
-		} else {
-			displayWord += string(char) + " "
-		}
+	for _, char := range letters(g.RevealedWord) {
+		displayWord += char + " "
 	}
 	sb.WriteString(wordStyle.Render(displayWord) + "\n\n")
 
 	// Display guessed letters
 	sb.WriteString("Guessed: ")
 	for _, letter := range g.GuessedLetters {
-		if strings.Contains(g.Word, letter) {
+		if g.contains(letter) {
 			sb.WriteString(correctStyle.Render(letter + " "))
 		} else {
 			sb.WriteString(incorrectStyle.Render(letter + " "))
@@ -184,7 +224,7 @@ func (g *Game) String() string {
 
 	// Display remaining letters
 	sb.WriteString("Available: ")
-	sb.WriteString(remainingStyle.Render(strings.Join(strings.Split(g.GetRemainingLetters(), ""), " ")))
+	sb.WriteString(remainingStyle.Render(strings.Join(letters(g.GetRemainingLetters()), " ")))
 	sb.WriteString("\n\n")
 
 	// Display game status
@@ -203,44 +243,120 @@ func (g *Game) String() string {
 	return sb.String()
 }
 
-// PlayGame starts a word guessing game session with the provided prompter
-func PlayGame(p Prompter) {
-	game := NewGame()
+// PlayGame starts a word guessing game session with the provided prompter.
+// Words are picked from the options' word list, and each allows the options'
+// number of wrong guesses. The player can keep playing new words, and the
+// returned result covers every word finished during the session.
+func PlayGame(p Prompter, out output.Output, rng *rand.Rand, opts Options) stats.Result {
+	words, topic := opts.Words, opts.Topic
+	if len(words) == 0 {
+		words, topic = WordList, "GitHub-related term"
+	}
+	if topic == "" {
+		topic = "word"
+	}
+
+	return playSession(p, out, func() (won, ok bool) {
+		var game *Game
+		if opts.Evil {
+			game = NewEvilGame(words, rng)
+			output.Secret(out, "candidates", output.Fields{"count": len(game.candidates), "length": len(letters(game.Word))})
+		} else {
+			game = NewGameFromList(words, rng)
+			output.Secret(out, "word", output.Fields{"word": game.Word})
+		}
+		game.MaxIncorrect = opts.MaxIncorrect
+		ok = playWord(p, out, game, topic, opts.Origins)
+		return game.HasWon, ok
+	})
+}
+
+// playSession plays words with play until the player stops, and returns the
+// result of every word finished. play reports whether the word was won, and
+// ok is false if it was interrupted by an input error, which ends the
+// session.
+func playSession(p Prompter, out output.Output, play func() (won, ok bool)) stats.Result {
+	var result stats.Result
+	winRun := 0
+
+	for {
+		won, ok := play()
+		if !ok {
+			return result
+		}
+
+		result.Played++
+		if won {
+			result.Wins++
+			winRun++
+			result.Streak = max(result.Streak, winRun)
+		} else {
+			result.Losses++
+			winRun = 0
+		}
 
-	fmt.Println(titleStyle.Render("\nWelcome to Word Guess!"))
-	fmt.Println(instructionStyle.Render("Guess the GitHub-related term one letter at a time."))
-	fmt.Println()
+		// Ask to play again
+		playAgain, err := p.Confirm("Play again?", true)
+		if err != nil {
+			out.Println("Error reading input:", err)
+			return result
+		}
+
+		if !playAgain {
+			out.Println(titleStyle.Render("Thanks for playing Word Guess!"))
+			return result
+		}
+	}
+}
+
+// playWord plays a single word until it is guessed or the guesses run out.
+// The introduction calls the word a topic, such as "GitHub-related term", and
+// the word's origin in origins, if it has one, is shown once the word is over.
+// Returns false if the game was interrupted by an input error.
+func playWord(p Prompter, out output.Output, game *Game, topic string, origins map[string]string) bool {
+	out.Println(titleStyle.Render("\nWelcome to Word Guess!"))
+	out.Println(instructionStyle.Render("Guess the " + topic + " one letter at a time."))
+	if game.candidates != nil {
+		out.Println(instructionStyle.Render("Evil mode: the word changes to dodge your guesses, as long as it fits what you have seen."))
+	}
+	out.Println()
 
 	// Main game loop
 	for !game.IsOver {
-		fmt.Println(game)
+		out.Println(game)
 
 		// Get player's guess
 		guess, err := p.Input("Enter a letter: ", "")
 		if err != nil {
-			fmt.Println("Error reading input:", err)
-			return
+			out.Println("Error reading input:", err)
+			return false
 		}
 
+		incorrectBefore := game.IncorrectGuesses
 		err = game.GuessLetter(guess)
 		if err != nil {
-			fmt.Println(incorrectStyle.Render(err.Error()))
+			out.Event("invalid_guess", output.Fields{"guess": guess, "error": err.Error()})
+			out.Println(incorrectStyle.Render(err.Error()))
+			continue
 		}
+		out.Event("guess", output.Fields{
+			"letter":            game.GuessedLetters[len(game.GuessedLetters)-1],
+			"correct":           game.IncorrectGuesses == incorrectBefore,
+			"revealed":          game.RevealedWord,
+			"incorrect_guesses": game.IncorrectGuesses,
+		})
 	}
 
-	// Show final state
-	fmt.Println(game)
-
-	// Ask to play again
-	playAgain, err := p.Confirm("Play again?", true)
-	if err != nil {
-		fmt.Println("Error reading input:", err)
-		return
+	// Show final state, once the word has been settled in evil mode
+	origin := origins[game.Word]
+	fields := output.Fields{"word": game.Word, "won": game.HasWon}
+	if origin != "" {
+		fields["origin"] = origin
 	}
-
-	if playAgain {
-		PlayGame(p)
-	} else {
-		fmt.Println(titleStyle.Render("Thanks for playing Word Guess!"))
+	out.Event("word_over", fields)
+	out.Println(game)
+	if origin != "" {
+		out.Println(instructionStyle.Render("The word came from " + origin))
 	}
+	return true
 }
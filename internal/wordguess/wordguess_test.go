@@ -1,10 +1,19 @@
 package wordguess
 
 import (
+	"math/rand"
 	"strings"
 	"testing"
+
+	"github.com/chrisreddington/gh-game/internal/output"
+	"github.com/chrisreddington/gh-game/internal/stats"
 )
 
+// newTestRand returns a deterministic random source for tests.
+func newTestRand() *rand.Rand {
+	return rand.New(rand.NewSource(1))
+}
+
 // MockPrompter is a mock implementation of the Prompter interface for testing.
 // It provides predefined responses for input, select, and confirm prompts to
 // enable deterministic testing of game interaction flows.
@@ -51,10 +60,10 @@ func (m *MockPrompter) Confirm(prompt string, defaultValue bool) (bool, error) {
 }
 
 func TestNewGame(t *testing.T) {
-	game := NewGame()
+	game := NewGame(newTestRand())
 
 	if game == nil {
-		t.Fatal("Expected NewGame() to return a game instance, got nil")
+		t.Fatal("Expected NewGame(newTestRand()) to return a game instance, got nil")
 	}
 
 	if game.IsOver {
@@ -79,8 +88,18 @@ func TestNewGame(t *testing.T) {
 	}
 }
 
+func TestNewGame_SameSeedSameWord(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		first := NewGame(rand.New(rand.NewSource(seed)))
+		second := NewGame(rand.New(rand.NewSource(seed)))
+		if first.Word != second.Word {
+			t.Errorf("seed %d picked different words: %q vs %q", seed, first.Word, second.Word)
+		}
+	}
+}
+
 func TestGuessLetter_ValidGuess(t *testing.T) {
-	game := NewGame()
+	game := NewGame(newTestRand())
 	originalWord := game.Word
 
 	// Always use a letter we know is in the word for this test
@@ -112,7 +131,7 @@ func TestGuessLetter_ValidGuess(t *testing.T) {
 }
 
 func TestGuessLetter_InvalidGuess(t *testing.T) {
-	game := NewGame()
+	game := NewGame(newTestRand())
 
 	// Test invalid input (not a letter)
 	err := game.GuessLetter("1")
@@ -137,7 +156,7 @@ func TestGuessLetter_InvalidGuess(t *testing.T) {
 }
 
 func TestGameOver_Win(t *testing.T) {
-	game := NewGame()
+	game := NewGame(newTestRand())
 	game.Word = "test"
 	// Set the RevealedWord to have only one underscore left
 	game.RevealedWord = "tes_"
@@ -158,7 +177,7 @@ func TestGameOver_Win(t *testing.T) {
 }
 
 func TestGameOver_Lose(t *testing.T) {
-	game := NewGame()
+	game := NewGame(newTestRand())
 	game.Word = "test"
 	game.IncorrectGuesses = MaxIncorrectGuesses - 1
 
@@ -178,7 +197,7 @@ func TestGameOver_Lose(t *testing.T) {
 }
 
 func TestGetRemainingLetters(t *testing.T) {
-	game := NewGame()
+	game := NewGame(newTestRand())
 	game.GuessedLetters = []string{"a", "e", "i", "o", "u"}
 
 	remaining := game.GetRemainingLetters()
@@ -200,23 +219,29 @@ func TestGetRemainingLetters(t *testing.T) {
 // Test isLetter function
 func TestIsLetter(t *testing.T) {
 	tests := []struct {
-		input    byte
+		input    string
 		expected bool
 	}{
-		{'a', true},
-		{'z', true},
-		{'A', true},
-		{'Z', true},
-		{'m', true},
-		{'0', false},
-		{'9', false},
-		{' ', false},
-		{'!', false},
-		{'@', false},
+		{"a", true},
+		{"z", true},
+		{"A", true},
+		{"Z", true},
+		{"m", true},
+		{"ü", true},
+		{"ß", true},
+		{"e\u0301", true}, // e with a combining acute accent
+		{"か", true},
+		{"0", false},
+		{"9", false},
+		{" ", false},
+		{"!", false},
+		{"@", false},
+		{"", false},
+		{"ab", false},
 	}
 
 	for _, tt := range tests {
-		t.Run(string(tt.input), func(t *testing.T) {
+		t.Run(tt.input, func(t *testing.T) {
 			if got := isLetter(tt.input); got != tt.expected {
 				t.Errorf("isLetter(%q) = %v, want %v", tt.input, got, tt.expected)
 			}
@@ -452,21 +477,33 @@ func TestPlayGame(t *testing.T) {
 		name             string
 		inputResponses   []string
 		confirmResponses []bool
+		maxIncorrect     int
+		wantResult       stats.Result
 	}{
 		{
 			name:             "Win game",
 			inputResponses:   []string{"t", "e", "s"},
 			confirmResponses: []bool{false},
+			wantResult:       stats.Result{Played: 1, Wins: 1, Streak: 1},
 		},
 		{
 			name:             "Lose game",
 			inputResponses:   []string{"a", "b", "c", "d", "f", "g", "h"},
 			confirmResponses: []bool{false},
+			wantResult:       stats.Result{Played: 1, Losses: 1},
 		},
 		{
 			name:             "Play again",
 			inputResponses:   []string{"t", "e", "s", "a", "b", "c", "d", "f", "g"},
 			confirmResponses: []bool{true, false}, // First true (play again), then false (quit)
+			wantResult:       stats.Result{Played: 2, Wins: 1, Losses: 1, Streak: 1},
+		},
+		{
+			name:             "Custom guess limit",
+			inputResponses:   []string{"a", "b"},
+			confirmResponses: []bool{false},
+			maxIncorrect:     2,
+			wantResult:       stats.Result{Played: 1, Losses: 1},
 		},
 	}
 
@@ -479,7 +516,9 @@ func TestPlayGame(t *testing.T) {
 
 			// This test checks that the function runs without errors
 			// Additional validation is done below with the confirm call count check
-			PlayGame(mp)
+			if got := PlayGame(mp, output.Discard, newTestRand(), Options{MaxIncorrect: tt.maxIncorrect}); got != tt.wantResult {
+				t.Errorf("PlayGame() = %+v, want %+v", got, tt.wantResult)
+			}
 
 			// Verify confirm was called the expected number of times
 			expectedConfirms := len(tt.confirmResponses)
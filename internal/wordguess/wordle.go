@@ -0,0 +1,416 @@
+package wordguess
+
+import (
+	"embed"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+
+	"github.com/chrisreddington/gh-game/internal/output"
+	"github.com/chrisreddington/gh-game/internal/stats"
+	"github.com/chrisreddington/gh-game/internal/theme"
+)
+
+const (
+	// WordleLength is the number of letters in a Wordle word
+	WordleLength = 5
+	// WordleAttempts is the number of guesses allowed in Wordle mode
+	WordleAttempts = 6
+)
+
+var (
+	// Stylized Wordle feedback, matching the emoji of the share grid
+	presentStyle = theme.Accent
+	absentStyle  = theme.Muted
+)
+
+// wordleFiles holds the bundled Wordle answers and the dictionary of other
+// words accepted as guesses
+//
+//go:embed wordle/*.txt
+var wordleFiles embed.FS
+
+// Mark is the feedback on one letter of a Wordle guess.
+type Mark int
+
+const (
+	// Absent letters are not in the word, or not as many times as guessed
+	Absent Mark = iota
+	// Present letters are in the word, in another place
+	Present
+	// Correct letters are in the right place
+	Correct
+)
+
+// Emoji returns the square shown for the mark in the share grid.
+func (m Mark) Emoji() string {
+	switch m {
+	case Correct:
+		return "🟩"
+	case Present:
+		return "🟨"
+	default:
+		return "⬜"
+	}
+}
+
+// String returns the name of the mark.
+func (m Mark) String() string {
+	switch m {
+	case Correct:
+		return "correct"
+	case Present:
+		return "present"
+	default:
+		return "absent"
+	}
+}
+
+// Score marks each letter of guess against word, which have the same number
+// of letters. Letters in the right place are Correct first, then the others
+// found in word are Present from left to right, until the letter has been
+// marked as many times as it is in word; the rest are Absent.
+func Score(guess, word string) []Mark {
+	guessLetters, wordLetters := letters(guess), letters(word)
+	marks := make([]Mark, len(guessLetters))
+	unmatched := map[string]int{}
+	for i, letter := range wordLetters {
+		if guessLetters[i] == letter {
+			marks[i] = Correct
+		} else {
+			unmatched[letter]++
+		}
+	}
+	for i, letter := range guessLetters {
+		if marks[i] != Correct && unmatched[letter] > 0 {
+			marks[i] = Present
+			unmatched[letter]--
+		}
+	}
+	return marks
+}
+
+// WordleAnswers returns the words of WordleLength letters in words, the
+// bundled answers if words is empty.
+// Returns an error if words has no words of that length.
+func WordleAnswers(words []string) ([]string, error) {
+	if len(words) == 0 {
+		return mustWordleFile("answers"), nil
+	}
+	var answers []string
+	for _, word := range words {
+		if len(letters(word)) == WordleLength {
+			answers = append(answers, word)
+		}
+	}
+	if len(answers) == 0 {
+		return nil, fmt.Errorf("no %d-letter words to guess", WordleLength)
+	}
+	return answers, nil
+}
+
+// WordleDictionary returns the words accepted as guesses when the answers are
+// picked from answers: the answers themselves, and the bundled answers and
+// dictionary of English words.
+func WordleDictionary(answers []string) map[string]bool {
+	dictionary := map[string]bool{}
+	for _, word := range slices.Concat(mustWordleFile("dictionary"), mustWordleFile("answers"), answers) {
+		dictionary[word] = true
+	}
+	return dictionary
+}
+
+// mustWordleFile returns the words of a bundled Wordle list, which are checked
+// by the tests, and panics if they cannot be read.
+func mustWordleFile(name string) []string {
+	file, err := wordleFiles.Open("wordle/" + name + ".txt")
+	if err != nil {
+		panic(err)
+	}
+	defer file.Close()
+	words, err := ParseWordList(file)
+	if err != nil {
+		panic(err)
+	}
+	return words
+}
+
+// WordleGame represents the state of a game of Wordle, where whole words are
+// guessed and each guess is marked letter by letter
+type WordleGame struct {
+	Word       string          // The word to be guessed
+	Guesses    []string        // Words that have been guessed
+	Marks      [][]Mark        // The marks of each guess
+	Dictionary map[string]bool // Words accepted as guesses
+	HardMode   bool            // Whether every hint revealed must be used in later guesses
+	IsOver     bool            // Whether the game is over
+	HasWon     bool            // Whether the player has won
+	Language   Language        // The language of the word
+}
+
+// NewWordleGame creates a new game of Wordle, picking the word to guess from
+// answers using rng. Guesses must be in dictionary.
+func NewWordleGame(answers []string, dictionary map[string]bool, rng *rand.Rand) *WordleGame {
+	lang := DetectLanguage(answers)
+	return &WordleGame{
+		Word:       fold(answers[rng.Intn(len(answers))], lang.Tag),
+		Dictionary: dictionary,
+		Language:   lang,
+	}
+}
+
+// Guess checks a guessed word and marks it.
+// Returns an error if the guess is not a word of the right length in the
+// dictionary, or in hard mode does not use the hints revealed so far.
+func (g *WordleGame) Guess(word string) error {
+	word = fold(strings.TrimSpace(word), g.Language.Tag)
+	if len(letters(word)) != WordleLength || !isWord(word) {
+		return fmt.Errorf("please enter a %d-letter word", WordleLength)
+	}
+	if !g.Dictionary[word] {
+		return fmt.Errorf("%q is not in the word list", word)
+	}
+	if g.HardMode {
+		if err := g.checkHints(word); err != nil {
+			return err
+		}
+	}
+
+	g.Guesses = append(g.Guesses, word)
+	g.Marks = append(g.Marks, Score(word, g.Word))
+	if word == g.Word {
+		g.IsOver = true
+		g.HasWon = true
+	} else if len(g.Guesses) >= WordleAttempts {
+		g.IsOver = true
+	}
+	return nil
+}
+
+// checkHints returns an error unless word keeps every correct letter of the
+// earlier guesses in its place and uses every present letter, as many times
+// as it was revealed.
+func (g *WordleGame) checkHints(word string) error {
+	wordLetters := letters(word)
+	for i, guess := range g.Guesses {
+		guessLetters := letters(guess)
+		for j, mark := range g.Marks[i] {
+			if mark == Correct && wordLetters[j] != guessLetters[j] {
+				return fmt.Errorf("letter %d must be %q", j+1, guessLetters[j])
+			}
+		}
+	}
+	for i, guess := range g.Guesses {
+		revealed := map[string]int{}
+		for j, letter := range letters(guess) {
+			if g.Marks[i][j] == Absent {
+				continue
+			}
+			revealed[letter]++
+			if countLetter(wordLetters, letter) < revealed[letter] {
+				return fmt.Errorf("guess must use %q", letter)
+			}
+		}
+	}
+	return nil
+}
+
+// countLetter returns how many times letter is in letters
+func countLetter(letters []string, letter string) int {
+	count := 0
+	for _, l := range letters {
+		if l == letter {
+			count++
+		}
+	}
+	return count
+}
+
+// letterMarks returns the best mark each guessed letter has been given
+func (g *WordleGame) letterMarks() map[string]Mark {
+	best := map[string]Mark{}
+	for i, guess := range g.Guesses {
+		for j, letter := range letters(guess) {
+			if mark, ok := best[letter]; !ok || g.Marks[i][j] > mark {
+				best[letter] = g.Marks[i][j]
+			}
+		}
+	}
+	return best
+}
+
+// ShareGrid returns the result as rows of emoji squares, one row per guess,
+// under a heading with the number of guesses taken, X if the word was not
+// guessed, and a * in hard mode. It gives nothing of the word away, so it can
+// be shared.
+func (g *WordleGame) ShareGrid() string {
+	taken := "X"
+	if g.HasWon {
+		taken = fmt.Sprint(len(g.Guesses))
+	}
+	hard := ""
+	if g.HardMode {
+		hard = "*"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "gh game wordguess %s/%d%s\n", taken, WordleAttempts, hard)
+	for _, marks := range g.Marks {
+		sb.WriteString("\n")
+		for _, mark := range marks {
+			sb.WriteString(mark.Emoji())
+		}
+	}
+	return sb.String()
+}
+
+// String returns a string representation of the current game state
+func (g *WordleGame) String() string {
+	var sb strings.Builder
+
+	// Display title
+	sb.WriteString(titleStyle.Render("W O R D L E") + "\n\n")
+
+	// Display the remaining guesses prominently, as in the classic game
+	left := WordleAttempts - len(g.Guesses)
+	guessesDisplay := fmt.Sprintf("Guesses Remaining: %d/%d", left, WordleAttempts)
+	if left > 3 {
+		sb.WriteString(correctStyle.Render(guessesDisplay))
+	} else if left > 1 {
+		sb.WriteString(instructionStyle.Render(guessesDisplay))
+	} else {
+		sb.WriteString(incorrectStyle.Render(guessesDisplay))
+	}
+	sb.WriteString("\n\n")
+
+	// Display each guess with its marks, and blanks for the guesses left
+	for i := range WordleAttempts {
+		if i >= len(g.Guesses) {
+			sb.WriteString(wordStyle.Render(strings.Repeat("_ ", WordleLength)) + "\n")
+			continue
+		}
+		var emoji strings.Builder
+		for j, letter := range letters(g.Guesses[i]) {
+			mark := g.Marks[i][j]
+			sb.WriteString(markStyle(mark).Render(letter) + " ")
+			emoji.WriteString(mark.Emoji())
+		}
+		sb.WriteString(" " + emoji.String() + "\n")
+	}
+	sb.WriteString("\n")
+
+	// Display the letters not ruled out, marked with what is known of them
+	marks := g.letterMarks()
+	sb.WriteString("Available: ")
+	for _, letter := range g.Language.Alphabet {
+		mark, guessed := marks[letter]
+		if !guessed {
+			sb.WriteString(remainingStyle.Render(letter) + " ")
+		} else if mark != Absent {
+			sb.WriteString(markStyle(mark).Render(letter) + " ")
+		}
+	}
+	sb.WriteString("\n\n")
+
+	// Display game status
+	if g.IsOver {
+		if g.HasWon {
+			sb.WriteString(correctStyle.Render(fmt.Sprintf("🎉 Congratulations! You guessed the word in %d/%d!", len(g.Guesses), WordleAttempts)))
+		} else {
+			sb.WriteString(incorrectStyle.Render("😔 Game over! The word was: ") +
+				wordStyle.Render(g.Word))
+		}
+		sb.WriteString("\n")
+	} else {
+		sb.WriteString(instructionStyle.Render(fmt.Sprintf("Guess a %d-letter word to continue.\n", WordleLength)))
+	}
+
+	return sb.String()
+}
+
+// markStyle returns the style of a letter given mark
+func markStyle(mark Mark) theme.Role {
+	switch mark {
+	case Correct:
+		return correctStyle
+	case Present:
+		return presentStyle
+	default:
+		return absentStyle
+	}
+}
+
+// PlayWordle starts a session of Wordle with the provided prompter. Words are
+// picked from WordleAnswers of the options' word list, and guesses may be any
+// of its WordleDictionary. The player can keep playing new words, and the
+// returned result covers every word finished during the session.
+func PlayWordle(p Prompter, out output.Output, rng *rand.Rand, opts Options) stats.Result {
+	answers, err := WordleAnswers(opts.Words)
+	if err != nil {
+		out.Println(incorrectStyle.Render(err.Error()))
+		return stats.Result{}
+	}
+	dictionary := WordleDictionary(answers)
+
+	return playSession(p, out, func() (won, ok bool) {
+		game := NewWordleGame(answers, dictionary, rng)
+		game.HardMode = opts.HardMode
+		output.Secret(out, "word", output.Fields{"word": game.Word})
+		ok = playWordle(p, out, game, opts.Origins[game.Word])
+		return game.HasWon, ok
+	})
+}
+
+// playWordle plays a single game of Wordle until the word is guessed or the
+// guesses run out, then shows the share grid and the word's origin, if it has
+// one.
+// Returns false if the game was interrupted by an input error.
+func playWordle(p Prompter, out output.Output, game *WordleGame, origin string) bool {
+	out.Println(titleStyle.Render("\nWelcome to Wordle!"))
+	out.Println(instructionStyle.Render(fmt.Sprintf("Guess the %d-letter word in %d tries.", WordleLength, WordleAttempts)))
+	if game.HardMode {
+		out.Println(instructionStyle.Render("Hard mode: every hint revealed must be used in later guesses."))
+	}
+	out.Println()
+
+	for !game.IsOver {
+		out.Println(game)
+
+		guess, err := p.Input("Enter a word: ", "")
+		if err != nil {
+			out.Println("Error reading input:", err)
+			return false
+		}
+
+		if err := game.Guess(guess); err != nil {
+			out.Event("invalid_guess", output.Fields{"guess": guess, "error": err.Error()})
+			out.Println(incorrectStyle.Render(err.Error()))
+			continue
+		}
+		marks := game.Marks[len(game.Marks)-1]
+		names := make([]string, len(marks))
+		for i, mark := range marks {
+			names[i] = mark.String()
+		}
+		out.Event("guess", output.Fields{
+			"word":    game.Guesses[len(game.Guesses)-1],
+			"marks":   names,
+			"guesses": len(game.Guesses),
+		})
+	}
+
+	// Show final state
+	fields := output.Fields{"word": game.Word, "won": game.HasWon, "guesses": len(game.Guesses), "share": game.ShareGrid()}
+	if origin != "" {
+		fields["origin"] = origin
+	}
+	out.Event("word_over", fields)
+	out.Println(game)
+	if origin != "" {
+		out.Println(instructionStyle.Render("The word came from " + origin))
+	}
+	out.Println(game.ShareGrid())
+	out.Println()
+	return true
+}
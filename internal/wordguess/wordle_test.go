@@ -0,0 +1,243 @@
+package wordguess
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/chrisreddington/gh-game/internal/output"
+	"github.com/chrisreddington/gh-game/internal/stats"
+)
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name  string
+		guess string
+		word  string
+		want  []Mark
+	}{
+		{"all correct", "merge", "merge", []Mark{Correct, Correct, Correct, Correct, Correct}},
+		{"none", "quick", "merge", []Mark{Absent, Absent, Absent, Absent, Absent}},
+		{"present", "grime", "merge", []Mark{Present, Present, Absent, Present, Correct}},
+		// Only one of the guessed e's can be marked, and the correct one wins
+		{"duplicate in guess, once in word", "geese", "stage", []Mark{Present, Absent, Absent, Present, Correct}},
+		// The extra e's are marked left to right while the word has some left
+		{"duplicate marked left to right", "eerie", "geese", []Mark{Present, Correct, Absent, Absent, Correct}},
+		{"more in word than in guess", "debug", "queue", []Mark{Absent, Present, Absent, Correct, Absent}},
+		{"unicode letters", "größe", "ößerg", []Mark{Present, Present, Present, Present, Present}},
+		{"unicode letter absent", "größe", "große", []Mark{Correct, Correct, Absent, Correct, Correct}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Score(tt.guess, tt.word); !slices.Equal(got, tt.want) {
+				t.Errorf("Score(%q, %q) = %v, want %v", tt.guess, tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWordleAnswers(t *testing.T) {
+	answers, err := WordleAnswers(nil)
+	if err != nil || len(answers) < 50 {
+		t.Fatalf("WordleAnswers(nil) = %d words, %v, want the bundled answers", len(answers), err)
+	}
+	for _, word := range answers {
+		if len(letters(word)) != WordleLength {
+			t.Errorf("bundled answer %q does not have %d letters", word, WordleLength)
+		}
+	}
+
+	got, err := WordleAnswers([]string{"commit", "merge", "größe", "git"})
+	if err != nil || !slices.Equal(got, []string{"merge", "größe"}) {
+		t.Errorf("WordleAnswers() = %v, %v, want [merge größe]", got, err)
+	}
+
+	if _, err := WordleAnswers([]string{"commit", "git"}); err == nil || !strings.Contains(err.Error(), "no 5-letter words") {
+		t.Errorf("WordleAnswers() error = %v, want no 5-letter words", err)
+	}
+}
+
+func TestWordleDictionary(t *testing.T) {
+	dictionary := WordleDictionary([]string{"größe"})
+	// The bundled answers are words too, whatever the answers are
+	for _, word := range []string{"about", "merge", "größe"} {
+		if !dictionary[word] {
+			t.Errorf("WordleDictionary() does not have %q", word)
+		}
+	}
+	for word := range dictionary {
+		if len(letters(word)) != WordleLength {
+			t.Errorf("WordleDictionary() has %q, which is not %d letters", word, WordleLength)
+		}
+	}
+}
+
+// newWordleGame returns a game of guessing word, accepting guesses from the
+// bundled dictionary
+func newWordleGame(word string, hardMode bool) *WordleGame {
+	game := NewWordleGame([]string{word}, WordleDictionary([]string{word}), newTestRand())
+	game.HardMode = hardMode
+	return game
+}
+
+func TestWordleGame_Guess(t *testing.T) {
+	tests := []struct {
+		name    string
+		guess   string
+		wantErr string
+	}{
+		{"valid", "Crane", ""},
+		{"too short", "cat", "5-letter word"},
+		{"too long", "branch", "5-letter word"},
+		{"not letters", "ab-cd", "5-letter word"},
+		{"not in dictionary", "zzzzz", "not in the word list"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newWordleGame("merge", false)
+			err := game.Guess(tt.guess)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Guess(%q) error = %v, want it to contain %q", tt.guess, err, tt.wantErr)
+				}
+				if len(game.Guesses) != 0 {
+					t.Errorf("Guess(%q) counted an invalid guess", tt.guess)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Guess(%q) unexpected error: %v", tt.guess, err)
+			}
+			if !slices.Equal(game.Guesses, []string{"crane"}) || len(game.Marks) != 1 {
+				t.Errorf("Guess() guesses = %v, marks = %v", game.Guesses, game.Marks)
+			}
+		})
+	}
+}
+
+func TestWordleGame_WinAndLose(t *testing.T) {
+	game := newWordleGame("merge", false)
+	for _, guess := range []string{"crane", "merge"} {
+		if err := game.Guess(guess); err != nil {
+			t.Fatalf("Guess(%q) unexpected error: %v", guess, err)
+		}
+	}
+	if !game.IsOver || !game.HasWon {
+		t.Errorf("after guessing the word IsOver = %v, HasWon = %v", game.IsOver, game.HasWon)
+	}
+
+	game = newWordleGame("merge", false)
+	for range WordleAttempts {
+		if err := game.Guess("crane"); err != nil {
+			t.Fatalf("Guess() unexpected error: %v", err)
+		}
+	}
+	if !game.IsOver || game.HasWon {
+		t.Errorf("after %d misses IsOver = %v, HasWon = %v", WordleAttempts, game.IsOver, game.HasWon)
+	}
+}
+
+func TestWordleGame_HardMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		guesses []string
+		wantErr string
+	}{
+		// crane against merge: r is present, e is correct
+		{"keeps hints", []string{"crane", "merge"}, ""},
+		{"correct letter moved", []string{"crane", "rebus"}, `letter 5 must be "e"`},
+		{"present letter dropped", []string{"crane", "those"}, `guess must use "r"`},
+		// egret against merge: both e's are present, so two must be used
+		{"repeated letter needs both", []string{"egret", "gorge"}, `guess must use "e"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newWordleGame("merge", true)
+			var err error
+			for _, guess := range tt.guesses {
+				if err = game.Guess(guess); err != nil {
+					break
+				}
+			}
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Guess() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Guess() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// The same guesses are fine outside hard mode
+	game := newWordleGame("merge", false)
+	for _, guess := range []string{"crane", "those"} {
+		if err := game.Guess(guess); err != nil {
+			t.Errorf("Guess(%q) outside hard mode unexpected error: %v", guess, err)
+		}
+	}
+}
+
+func TestWordleGame_ShareGrid(t *testing.T) {
+	game := newWordleGame("merge", true)
+	for _, guess := range []string{"crane", "merge"} {
+		if err := game.Guess(guess); err != nil {
+			t.Fatalf("Guess(%q) unexpected error: %v", guess, err)
+		}
+	}
+	want := "gh game wordguess 2/6*\n\n⬜🟨⬜⬜🟩\n🟩🟩🟩🟩🟩"
+	if got := game.ShareGrid(); got != want {
+		t.Errorf("ShareGrid() = %q, want %q", got, want)
+	}
+
+	game = newWordleGame("merge", false)
+	for range WordleAttempts {
+		game.Guess("crane")
+	}
+	if got := game.ShareGrid(); !strings.HasPrefix(got, "gh game wordguess X/6\n") || strings.Contains(got, "merge") {
+		t.Errorf("ShareGrid() of a lost game = %q", got)
+	}
+}
+
+func TestWordleGame_String(t *testing.T) {
+	game := newWordleGame("merge", false)
+	if err := game.Guess("crane"); err != nil {
+		t.Fatal(err)
+	}
+	got := game.String()
+	for _, want := range []string{"W O R D L E", "Guesses Remaining: 5/6", "⬜🟨⬜⬜🟩", "_ _ _ _ _", "Guess a 5-letter word"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("String() does not contain %q\nGot: %q", want, got)
+		}
+	}
+	// Letters ruled out are no longer available
+	available, _, _ := strings.Cut(got[strings.Index(got, "Available:"):], "\n")
+	for _, letter := range []string{"c", "a", "n"} {
+		if strings.Contains(available, " "+letter+" ") {
+			t.Errorf("String() still offers %q: %q", letter, available)
+		}
+	}
+}
+
+func TestPlayWordle(t *testing.T) {
+	mp := &MockPrompter{
+		InputResponses:   []string{"cat", "crane", "merge"},
+		ConfirmResponses: []bool{false},
+	}
+	var buf bytes.Buffer
+	got := PlayWordle(mp, output.NewText(&buf), newTestRand(), Options{Words: []string{"merge"}})
+	if want := (stats.Result{Played: 1, Wins: 1, Streak: 1}); got != want {
+		t.Errorf("PlayWordle() = %+v, want %+v", got, want)
+	}
+	for _, want := range []string{"please enter a 5-letter word", "gh game wordguess 2/6\n\n⬜🟨⬜⬜🟩\n🟩🟩🟩🟩🟩"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("PlayWordle() output does not contain %q:\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package wordguess
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultCategory is the category WordList is loaded from
+const DefaultCategory = "github"
+
+// categoryFiles holds the bundled word list of each category, one file per
+// category named after it
+//
+//go:embed words/*.txt
+var categoryFiles embed.FS
+
+// Categories returns the names of the bundled word list categories, in
+// alphabetical order.
+func Categories() []string {
+	entries, _ := categoryFiles.ReadDir("words")
+	var names []string
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".txt"))
+	}
+	slices.Sort(names)
+	return names
+}
+
+// CategoryWords returns the words of a bundled category.
+// Returns an error if there is no category with that name.
+func CategoryWords(name string) ([]string, error) {
+	file, err := categoryFiles.Open(path.Join("words", name+".txt"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid category %q: must be one of %s", name, strings.Join(Categories(), ", "))
+	}
+	defer file.Close()
+	return ParseWordList(file)
+}
+
+// LoadWordList reads a word list from a file in the format ParseWordList
+// accepts.
+func LoadWordList(filename string) ([]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	words, err := ParseWordList(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return words, nil
+}
+
+// ParseWordList reads one word per line. Surrounding spaces are trimmed and
+// words are lowercased as guesses are, blank lines and lines starting with #
+// are skipped, and words already in the list are dropped.
+// Returns an error if a word contains anything but letters, or the list has
+// no words.
+func ParseWordList(r io.Reader) ([]string, error) {
+	var words []string
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		word := fold(strings.TrimSpace(scanner.Text()), language.Und)
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		if !isWord(word) {
+			return nil, fmt.Errorf("line %d: invalid word %q: must only contain letters", line, word)
+		}
+		if !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("no words found")
+	}
+	return words, nil
+}
+
+// isWord reports whether word is made of letters only.
+func isWord(word string) bool {
+	return !slices.ContainsFunc(letters(word), func(letter string) bool { return !isLetter(letter) })
+}
+
+// mustCategory returns the words of a bundled category, which are checked by
+// the tests, and panics if they cannot be read.
+func mustCategory(name string) []string {
+	words, err := CategoryWords(name)
+	if err != nil {
+		panic(err)
+	}
+	return words
+}
@@ -0,0 +1,140 @@
+package wordguess
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/chrisreddington/gh-game/internal/output"
+	"github.com/chrisreddington/gh-game/internal/stats"
+)
+
+func TestParseWordList(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:  "one word per line",
+			input: "octocat\nhubot\n",
+			want:  []string{"octocat", "hubot"},
+		},
+		{
+			name:  "comments, blank lines and spaces skipped",
+			input: "# team jargon\n\n  octocat  \n\n# more\nhubot",
+			want:  []string{"octocat", "hubot"},
+		},
+		{
+			name:  "lowercased and duplicates removed",
+			input: "Octocat\nhubot\nOCTOCAT\nhubot\n",
+			want:  []string{"octocat", "hubot"},
+		},
+		{
+			name:    "non-letter word",
+			input:   "octocat\npull-request\n",
+			wantErr: `line 2: invalid word "pull-request": must only contain letters`,
+		},
+		{
+			name:    "digits",
+			input:   "# list\nhttp2\n",
+			wantErr: "line 2",
+		},
+		{
+			name:    "no words",
+			input:   "# only a comment\n\n",
+			wantErr: "no words found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseWordList(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ParseWordList() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseWordList() unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseWordList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategories(t *testing.T) {
+	categories := Categories()
+	for _, want := range []string{"actions", "git", "github", "security"} {
+		if !slices.Contains(categories, want) {
+			t.Errorf("Categories() = %v, want it to include %q", categories, want)
+		}
+	}
+
+	// Every bundled list must load, since a bad one would only fail when
+	// chosen
+	for _, category := range categories {
+		if words, err := CategoryWords(category); err != nil || len(words) < 10 {
+			t.Errorf("CategoryWords(%q) = %d words, %v, want at least 10 words", category, len(words), err)
+		}
+	}
+
+	if _, err := CategoryWords("nope"); err == nil || !strings.Contains(err.Error(), "must be one of actions") {
+		t.Errorf("CategoryWords(nope) error = %v, want the categories listed", err)
+	}
+}
+
+func TestWordList_DefaultCategory(t *testing.T) {
+	words, err := CategoryWords(DefaultCategory)
+	if err != nil {
+		t.Fatalf("CategoryWords() unexpected error: %v", err)
+	}
+	if !slices.Equal(WordList, words) || !slices.Contains(WordList, "copilot") {
+		t.Errorf("WordList = %v, want the %s category", WordList, DefaultCategory)
+	}
+}
+
+func TestLoadWordList(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "jargon.txt")
+	if err := os.WriteFile(filename, []byte("Mona\nhubot\nmona\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	words, err := LoadWordList(filename)
+	if err != nil {
+		t.Fatalf("LoadWordList() unexpected error: %v", err)
+	}
+	if !slices.Equal(words, []string{"mona", "hubot"}) {
+		t.Errorf("LoadWordList() = %v, want [mona hubot]", words)
+	}
+
+	if _, err := LoadWordList(filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("LoadWordList() of a missing file did not return an error")
+	}
+
+	bad := filepath.Join(dir, "bad.txt")
+	if err := os.WriteFile(bad, []byte("mona lisa\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadWordList(bad); err == nil || !strings.Contains(err.Error(), bad+": line 1") {
+		t.Errorf("LoadWordList() error = %v, want the file and line named", err)
+	}
+}
+
+func TestPlayGame_CustomWords(t *testing.T) {
+	mp := &MockPrompter{
+		InputResponses:   []string{"m", "o", "n", "a"},
+		ConfirmResponses: []bool{false},
+	}
+	got := PlayGame(mp, output.Discard, newTestRand(), Options{Words: []string{"mona"}})
+	if want := (stats.Result{Played: 1, Wins: 1, Streak: 1}); got != want {
+		t.Errorf("PlayGame() = %+v, want %+v", got, want)
+	}
+}